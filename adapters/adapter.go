@@ -45,6 +45,9 @@ func For(task models.TaskSpec, store *store.Store) (ac Adapter, err error) {
 	case "nooppend":
 		ac = &NoOpPend{}
 		err = unmarshalParams(task.Params, ac)
+	case "sleep":
+		ac = &Sleep{}
+		err = unmarshalParams(task.Params, ac)
 	default:
 		if bt, err := store.BridgeTypeFor(task.Type); err != nil {
 			return nil, fmt.Errorf("%s is not a supported adapter type", task.Type)
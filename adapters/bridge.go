@@ -18,16 +18,18 @@ type Bridge struct {
 }
 
 // Perform sends a POST request containing the JSON of the input RunResult to
-// the external adapter specified in the BridgeType.
+// the external adapter specified in the BridgeType. The request carries the
+// idempotency key of the task run, in its body and as the Idempotency-Key
+// header, which stays the same when the request is retried.
 // It records the RunResult returned to it, and optionally marks the RunResult pending.
 //
 // If the Perform is resumed with a pending RunResult, the RunResult is marked
 // not pending and the RunResult is returned.
-func (ba *Bridge) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+func (ba *Bridge) Perform(input models.RunResult, store *store.Store) models.RunResult {
 	if input.Pending {
 		return markNotPending(input)
 	}
-	return ba.handleNewRun(input)
+	return ba.handleNewRun(input, store)
 }
 
 func markNotPending(input models.RunResult) models.RunResult {
@@ -35,13 +37,23 @@ func markNotPending(input models.RunResult) models.RunResult {
 	return input
 }
 
-func (ba *Bridge) handleNewRun(input models.RunResult) models.RunResult {
+func (ba *Bridge) handleNewRun(input models.RunResult, store *store.Store) models.RunResult {
 	in, err := json.Marshal(&bridgePayload{input})
 	if err != nil {
 		return baRunResultError(input, "marshaling request body", err)
 	}
 
-	resp, err := http.Post(ba.URL.String(), "application/json", bytes.NewBuffer(in))
+	resp, err := send(store, ba.URL.URL, func() (*http.Response, error) {
+		request, err := http.NewRequest("POST", ba.URL.String(), bytes.NewBuffer(in))
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("Content-Type", "application/json")
+		if input.IdempotencyKey != "" {
+			request.Header.Set("Idempotency-Key", input.IdempotencyKey)
+		}
+		return http.DefaultClient.Do(request)
+	})
 	if err != nil {
 		return baRunResultError(input, "POST request", err)
 	}
@@ -76,11 +88,13 @@ type bridgePayload struct {
 
 func (bp bridgePayload) MarshalJSON() ([]byte, error) {
 	anon := struct {
-		JobRunID string      `json:"id"`
-		Data     models.JSON `json:"data"`
+		JobRunID       string      `json:"id"`
+		IdempotencyKey string      `json:"idempotencyKey,omitempty"`
+		Data           models.JSON `json:"data"`
 	}{
-		JobRunID: bp.JobRunID,
-		Data:     bp.Data,
+		JobRunID:       bp.JobRunID,
+		IdempotencyKey: bp.IdempotencyKey,
+		Data:           bp.Data,
 	}
 	return json.Marshal(anon)
 }
@@ -2,6 +2,10 @@ package adapters_test
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/smartcontractkit/chainlink/adapters"
@@ -94,3 +98,33 @@ func TestBridge_Perform_FromPending(t *testing.T) {
 		})
 	}
 }
+
+func TestBridge_Perform_SendsIdempotencyKey(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	runID := utils.NewBytes32ID()
+	key := utils.NewBytes32ID()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, key, r.Header.Get("Idempotency-Key"))
+		b, err := ioutil.ReadAll(r.Body)
+		assert.Nil(t, err)
+		assert.JSONEq(t, fmt.Sprintf(`{"id":"%v","idempotencyKey":"%v","data":{"value":"lot 49"}}`, runID, key), string(b))
+		io.WriteString(w, `{"pending":true}`)
+	}))
+	defer server.Close()
+
+	ba := &adapters.Bridge{cltest.NewBridgeType("auctionBidding", server.URL)}
+	input := cltest.RunResultWithValue("lot 49")
+	input.JobRunID = runID
+	input.IdempotencyKey = key
+
+	for i := 0; i < 2; i++ {
+		result := ba.Perform(input, store)
+		assert.True(t, result.Pending)
+	}
+	assert.Equal(t, 2, requests)
+}
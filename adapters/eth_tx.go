@@ -1,21 +1,52 @@
 package adapters
 
 import (
+	"encoding/json"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/smartcontractkit/chainlink/logger"
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/smartcontractkit/chainlink/utils"
 )
 
 // EthTx holds the Address to send the result to and the FunctionSelector
-// to execute.
+// to execute. The address can also be given as an ENS name, in which case
+// it is resolved each time the adapter is performed.
 type EthTx struct {
 	Address          common.Address          `json:"address"`
+	ENSName          string                  `json:"ensName,omitempty"`
 	FunctionSelector models.FunctionSelector `json:"functionSelector"`
 	DataPrefix       hexutil.Bytes           `json:"dataPrefix"`
 }
 
+// UnmarshalJSON parses the adapter's params, accepting either an address
+// or an ENS name for the "address" field.
+func (etx *EthTx) UnmarshalJSON(input []byte) error {
+	type Alias EthTx
+	var aux struct {
+		Alias
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(input, &aux); err != nil {
+		return err
+	}
+
+	*etx = EthTx(aux.Alias)
+	if aux.Address != "" {
+		address, name, err := models.ParseAddressOrENSName(aux.Address)
+		if err != nil {
+			return err
+		}
+		etx.Address = address
+		if name != "" {
+			etx.ENSName = name
+		}
+	}
+	return nil
+}
+
 // Perform creates the run result for the transaction if the existing run result
 // is not currently pending. Then it confirms the transaction was confirmed on
 // the blockchain.
@@ -42,7 +73,14 @@ func createTxRunResult(
 		return input.WithError(err)
 	}
 
-	attempt, err := store.TxManager.CreateTx(e.Address, data)
+	to := e.Address
+	if e.ENSName != "" {
+		if to, err = store.TxManager.ResolveENSName(e.ENSName); err != nil {
+			return input.WithError(err)
+		}
+	}
+
+	attempt, err := store.TxManager.CreateTx(to, data)
 	if err != nil {
 		return input.WithError(err)
 	}
@@ -69,5 +107,33 @@ func ensureTxRunResult(input models.RunResult, store *store.Store) models.RunRes
 	} else if !confirmed {
 		return input.MarkPending()
 	}
+	recordRunCost(hash, input.JobRunID, store)
 	return input.WithValue(hash.String())
 }
+
+// recordRunCost saves the cost of the confirmed transaction against the run
+// that sent it. Failing to do so does not fail the run.
+func recordRunCost(hash common.Hash, runID string, store *store.Store) {
+	if runID == "" {
+		return
+	}
+	run, err := store.FindJobRun(runID)
+	if err != nil {
+		logger.Warnw("Unable to find run to record its cost", "run", runID, "err", err)
+		return
+	}
+	attempt := models.TxAttempt{}
+	if err := store.One("Hash", hash, &attempt); err != nil {
+		logger.Warnw("Unable to find transaction to record its cost", "hash", hash, "err", err)
+		return
+	}
+	tx := models.Tx{}
+	if err := store.One("ID", attempt.TxID, &tx); err != nil {
+		logger.Warnw("Unable to find transaction to record its cost", "hash", hash, "err", err)
+		return
+	}
+	cost := models.NewRunCost(run, tx)
+	if err := store.Save(&cost); err != nil {
+		logger.Warnw("Unable to record run cost", "run", runID, "err", err)
+	}
+}
@@ -198,3 +198,47 @@ func TestEthTxAdapter_Perform_WithError(t *testing.T) {
 	assert.True(t, output.HasError())
 	assert.Equal(t, output.Error(), "Cannot connect to nodes")
 }
+
+func TestEthTxAdapter_Perform_RecordsRunCost(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	store := app.Store
+	config := store.Config
+
+	sentAt := uint64(23456)
+
+	ethMock := app.MockEthClient()
+	ethMock.Register("eth_getTransactionReceipt", strpkg.TxReceipt{
+		Hash:        cltest.NewHash(),
+		BlockNumber: cltest.BigHexInt(sentAt),
+		GasUsed:     hexutil.Uint64(50000),
+	})
+	ethMock.Register("eth_blockNumber", utils.Uint64ToHex(sentAt+config.EthMinConfirmations))
+
+	job := models.NewJob()
+	run := job.NewRun()
+	assert.Nil(t, store.Save(&run))
+	tx := cltest.NewTx(cltest.NewAddress(), sentAt)
+	assert.Nil(t, store.Save(tx))
+	a, _ := store.AddAttempt(tx, tx.EthTx(big.NewInt(20)), sentAt)
+	input := cltest.RunResultWithValue(a.Hash.String())
+	input.JobRunID = run.ID
+
+	adapter := adapters.EthTx{}
+	output := adapter.Perform(input.MarkPending(), store)
+	assert.False(t, output.Pending)
+	assert.False(t, output.HasError())
+
+	costs, err := store.RunCostsFor(job.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(costs))
+	assert.Equal(t, run.ID, costs[0].JobRunID)
+	assert.Equal(t, a.Hash, costs[0].TxHash)
+	assert.Equal(t, uint64(50000), costs[0].GasUsed)
+	assert.Equal(t, big.NewInt(20), costs[0].GasPrice)
+	assert.Equal(t, big.NewInt(1000000), costs[0].EthSpent)
+
+	ethMock.EnsureAllCalled(t)
+}
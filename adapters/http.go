@@ -5,51 +5,118 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 
+	"github.com/smartcontractkit/chainlink/logger"
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
 )
 
 // HTTPGet requires a URL which is used for a GET request when the adapter is called.
+// If CacheTTL is set, the response is reused by any task making the same
+// request until it expires. If FallbackURL is set, it is requested whenever
+// the URL fails.
 type HTTPGet struct {
-	URL models.WebURL `json:"url"`
+	URL         models.WebURL   `json:"url"`
+	FallbackURL models.WebURL   `json:"fallbackUrl"`
+	CacheTTL    models.Duration `json:"cacheTTL"`
 }
 
 // Perform ensures that the adapter's URL responds to a GET request without
 // errors and returns the response body as the "value" field of the result.
-func (hga *HTTPGet) Perform(input models.RunResult, _ *store.Store) models.RunResult {
-	response, err := http.Get(hga.URL.String())
+func (hga *HTTPGet) Perform(input models.RunResult, store *store.Store) models.RunResult {
+	body, err := withFallback(hga.URL, hga.FallbackURL, func(u models.WebURL) (string, error) {
+		return hga.get(u, store)
+	})
 	if err != nil {
 		return input.WithError(err)
 	}
+	return input.WithValue(body)
+}
 
-	defer response.Body.Close()
+func (hga *HTTPGet) get(u models.WebURL, store *store.Store) (string, error) {
+	url := u.String()
+	return withCache(store, hga.CacheTTL, "GET "+url, func() (string, error) {
+		return readResponse(send(store, u.URL, func() (*http.Response, error) {
+			return http.Get(url)
+		}))
+	})
+}
 
-	bytes, err := ioutil.ReadAll(response.Body)
-	body := string(bytes)
+// HTTPPost requires a URL which is used for a POST request when the adapter is called.
+// If CacheTTL is set, the response is reused by any task posting the same
+// data to the same URL until it expires. If FallbackURL is set, it is
+// posted to whenever the URL fails.
+type HTTPPost struct {
+	URL         models.WebURL   `json:"url"`
+	FallbackURL models.WebURL   `json:"fallbackUrl"`
+	CacheTTL    models.Duration `json:"cacheTTL"`
+}
+
+// Perform ensures that the adapter's URL responds to a POST request without
+// errors and returns the response body as the "value" field of the result.
+func (hpa *HTTPPost) Perform(input models.RunResult, store *store.Store) models.RunResult {
+	data := input.Data.String()
+	body, err := withFallback(hpa.URL, hpa.FallbackURL, func(u models.WebURL) (string, error) {
+		return hpa.post(u, data, store)
+	})
 	if err != nil {
 		return input.WithError(err)
 	}
+	return input.WithValue(body)
+}
 
-	if response.StatusCode >= 400 {
-		return input.WithError(fmt.Errorf(body))
-	}
+func (hpa *HTTPPost) post(u models.WebURL, data string, store *store.Store) (string, error) {
+	url := u.String()
+	return withCache(store, hpa.CacheTTL, "POST "+url+" "+data, func() (string, error) {
+		return readResponse(send(store, u.URL, func() (*http.Response, error) {
+			return http.Post(url, "application/json", bytes.NewBufferString(data))
+		}))
+	})
+}
 
-	return input.WithValue(body)
+// withFallback fetches from the primary URL, and from the fallback if one
+// is set and the primary fails.
+func withFallback(primary, fallback models.WebURL, fetch func(models.WebURL) (string, error)) (string, error) {
+	body, err := fetch(primary)
+	if err == nil || fallback.URL == nil {
+		return body, err
+	}
+	logger.Warnw("Request failed, trying fallback", "url", primary.String(), "fallbackUrl", fallback.String(), "err", err)
+	body, fallbackErr := fetch(fallback)
+	if fallbackErr != nil {
+		return "", fmt.Errorf("%v; fallback: %v", err, fallbackErr)
+	}
+	return body, nil
 }
 
-// HTTPPost requires a URL which is used for a POST request when the adapter is called.
-type HTTPPost struct {
-	URL models.WebURL `json:"url"`
+// send makes the request once the host's circuit and rate limit allow it,
+// recording connection failures and server errors against the host. Hosts
+// are told apart by port too, as different ports are often different
+// services.
+func send(store *store.Store, u *url.URL, request func() (*http.Response, error)) (*http.Response, error) {
+	if store == nil || u == nil {
+		return request()
+	}
+	host := u.Host
+	if err := store.Breaker.Allow(host); err != nil {
+		return nil, err
+	}
+	if err := store.Limiter.Wait(host, store.Done()); err != nil {
+		return nil, err
+	}
+	response, err := request()
+	if err != nil || response.StatusCode >= 500 {
+		store.Breaker.Failure(host)
+	} else {
+		store.Breaker.Success(host)
+	}
+	return response, err
 }
 
-// Perform ensures that the adapter's URL responds to a POST request without
-// errors and returns the response body as the "value" field of the result.
-func (hpa *HTTPPost) Perform(input models.RunResult, _ *store.Store) models.RunResult {
-	reqBody := bytes.NewBufferString(input.Data.String())
-	response, err := http.Post(hpa.URL.String(), "application/json", reqBody)
+func readResponse(response *http.Response, err error) (string, error) {
 	if err != nil {
-		return input.WithError(err)
+		return "", err
 	}
 
 	defer response.Body.Close()
@@ -57,12 +124,22 @@ func (hpa *HTTPPost) Perform(input models.RunResult, _ *store.Store) models.RunR
 	bytes, err := ioutil.ReadAll(response.Body)
 	body := string(bytes)
 	if err != nil {
-		return input.WithError(err)
+		return "", err
 	}
 
 	if response.StatusCode >= 400 {
-		return input.WithError(fmt.Errorf(body))
+		return "", fmt.Errorf(body)
 	}
+	return body, nil
+}
 
-	return input.WithValue(body)
+// withCache returns the cached response for the key if there is one, and
+// otherwise fetches and caches it for the TTL, sharing the request with any
+// task that asks for the same key meanwhile. Errors are never cached, and a
+// zero TTL disables caching.
+func withCache(store *store.Store, ttl models.Duration, key string, fetch func() (string, error)) (string, error) {
+	if ttl.Duration <= 0 {
+		return fetch()
+	}
+	return store.Cache.Fetch(key, ttl.Duration, store.Clock, fetch)
 }
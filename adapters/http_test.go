@@ -1,10 +1,17 @@
 package adapters_test
 
 import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/adapters"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
+	strpkg "github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/stretchr/testify/assert"
 )
@@ -102,3 +109,139 @@ func TestHttpPost_Perform(t *testing.T) {
 		})
 	}
 }
+
+func TestHttpGet_Perform_CacheTTL(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	clock := cltest.UseSettableClock(store)
+	now := time.Now()
+	clock.SetTime(now)
+
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		io.WriteString(w, fmt.Sprintf("response %v", hits))
+	}))
+	defer server.Close()
+
+	hga := adapters.HTTPGet{
+		URL:      cltest.MustParseWebURL(server.URL),
+		CacheTTL: models.Duration{Duration: time.Minute},
+	}
+	uncached := adapters.HTTPGet{URL: cltest.MustParseWebURL(server.URL)}
+	input := cltest.RunResultWithValue("inputValue")
+
+	tests := []struct {
+		name    string
+		adapter adapters.HTTPGet
+		at      time.Time
+		want    string
+	}{
+		{"first request", hga, now, "response 1"},
+		{"within ttl", hga, now.Add(59 * time.Second), "response 1"},
+		{"without ttl", uncached, now.Add(59 * time.Second), "response 2"},
+		{"expired", hga, now.Add(time.Minute), "response 3"},
+	}
+
+	for _, test := range tests {
+		clock.SetTime(test.at)
+		val, err := test.adapter.Perform(input, store).Value()
+		assert.Nil(t, err)
+		assert.Equal(t, test.want, val, test.name)
+	}
+}
+
+func TestHttpGet_Perform_CacheTTL_SharesInFlightRequest(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	var hits int32
+	received := make(chan struct{}, 1)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		received <- struct{}{}
+		<-release
+		io.WriteString(w, "response")
+	}))
+	defer server.Close()
+
+	hga := adapters.HTTPGet{
+		URL:      cltest.MustParseWebURL(server.URL),
+		CacheTTL: models.Duration{Duration: time.Minute},
+	}
+	input := cltest.RunResultWithValue("inputValue")
+
+	results := make(chan models.RunResult, 3)
+	perform := func() { results <- hga.Perform(input, store) }
+	go perform()
+	<-received
+	go perform()
+	go perform()
+	close(release)
+
+	for i := 0; i < 3; i++ {
+		val, err := (<-results).Value()
+		assert.Nil(t, err)
+		assert.Equal(t, "response", val)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+}
+
+func TestHttpGet_Perform_CircuitBreakerAndFallback(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	clock := cltest.UseSettableClock(store)
+	now := time.Now()
+	clock.SetTime(now)
+	store.Breaker = strpkg.NewCircuitBreaker(2, time.Minute, clock)
+
+	hits := 0
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(500)
+	}))
+	defer primary.Close()
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "fallback")
+	}))
+	defer fallback.Close()
+
+	withFallback := adapters.HTTPGet{
+		URL:         cltest.MustParseWebURL(primary.URL),
+		FallbackURL: cltest.MustParseWebURL(fallback.URL),
+	}
+	withoutFallback := adapters.HTTPGet{URL: cltest.MustParseWebURL(primary.URL)}
+	input := cltest.RunResultWithValue("inputValue")
+
+	tests := []struct {
+		name      string
+		adapter   adapters.HTTPGet
+		at        time.Time
+		want      string
+		wantError string
+		wantHits  int
+	}{
+		{"first failure", withFallback, now, "fallback", "", 1},
+		{"opens circuit", withFallback, now, "fallback", "", 2},
+		{"open", withFallback, now.Add(59 * time.Second), "fallback", "", 2},
+		{"open without fallback", withoutFallback, now.Add(59 * time.Second), "", "Circuit open", 2},
+		{"after cooldown", withoutFallback, now.Add(time.Minute), "", "", 3},
+	}
+
+	for _, test := range tests {
+		clock.SetTime(test.at)
+		result := test.adapter.Perform(input, store)
+		if test.wantError != "" {
+			assert.Contains(t, result.Error(), test.wantError, test.name)
+		} else if test.want != "" {
+			val, err := result.Value()
+			assert.Nil(t, err)
+			assert.Equal(t, test.want, val, test.name)
+		}
+		assert.Equal(t, test.wantHits, hits, test.name)
+	}
+}
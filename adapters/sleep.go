@@ -0,0 +1,20 @@
+package adapters
+
+import (
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// Sleep adapter holds the run's remaining tasks until the given time.
+type Sleep struct {
+	Until models.Time `json:"until"`
+}
+
+// Perform returns a pending RunResult until the time has passed, and then
+// passes the input on unchanged.
+func (adapter *Sleep) Perform(input models.RunResult, store *store.Store) models.RunResult {
+	if store.Clock.Now().Before(adapter.Until.Time) {
+		return input.MarkPending()
+	}
+	return markNotPending(input)
+}
@@ -0,0 +1,33 @@
+package adapters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSleep_Perform(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	clock := cltest.UseSettableClock(store)
+	until := cltest.ParseISO8601("2018-06-01T00:00:00.000Z")
+	adapter := adapters.Sleep{Until: models.Time{Time: until}}
+	input := cltest.RunResultWithValue("100")
+
+	clock.SetTime(until.Add(-time.Second))
+	result := adapter.Perform(input, store)
+	assert.True(t, result.Pending)
+	assert.Nil(t, result.GetError())
+
+	clock.SetTime(until)
+	result = adapter.Perform(result, store)
+	assert.False(t, result.Pending)
+	val, err := result.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "100", val)
+}
@@ -74,7 +74,7 @@ func render(name string, table *tablewriter.Table) {
 }
 
 func jobRowToStrings(job models.JobSpec) []string {
-	p := presenters.JobSpec{job, nil}
+	p := presenters.JobSpec{JobSpec: job}
 	return []string{
 		p.ID,
 		p.FriendlyCreatedAt(),
@@ -29,7 +29,7 @@ func TestRendererTableRenderShowJob(t *testing.T) {
 	r := cmd.RendererTable{ioutil.Discard}
 	job := cltest.NewJobWithWebInitiator()
 	run := job.NewRun()
-	p := presenters.JobSpec{job, []models.JobRun{run}}
+	p := presenters.JobSpec{JobSpec: job, Runs: []models.JobRun{run}}
 	assert.Nil(t, r.Render(&p))
 }
 
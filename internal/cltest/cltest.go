@@ -266,6 +266,23 @@ func BasicAuthPatch(url string, contentType string, body io.Reader) *http.Respon
 	return resp
 }
 
+func BasicAuthDelete(url string) *http.Response {
+	resp, err := utils.BasicAuthDelete(Username, Password, url)
+	mustNotErr(err)
+	return resp
+}
+
+// TokenAuthRequest sends a request authenticated with the given API token.
+func TokenAuthRequest(method, url, token string, body io.Reader) *http.Response {
+	request, err := http.NewRequest(method, url, body)
+	mustNotErr(err)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(request)
+	mustNotErr(err)
+	return resp
+}
+
 func ParseResponseBody(resp *http.Response) []byte {
 	b, err := ioutil.ReadAll(resp.Body)
 	mustNotErr(err)
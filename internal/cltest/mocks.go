@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -27,7 +28,7 @@ func (ta *TestApplication) MockEthClient() *EthMock {
 
 func MockEthOnStore(s *store.Store) *EthMock {
 	mock := &EthMock{}
-	eth := &store.EthClient{mock}
+	eth := &store.EthClient{CallerSubscriber: mock}
 	s.TxManager.EthClient = eth
 	return mock
 }
@@ -269,6 +270,28 @@ func (NeverClock) Now() time.Time {
 	return time.Now()
 }
 
+// TriggerClock only fires the channels returned by After when Trigger is
+// called.
+type TriggerClock struct {
+	triggers chan time.Time
+}
+
+func NewTriggerClock() *TriggerClock {
+	return &TriggerClock{triggers: make(chan time.Time)}
+}
+
+func (tc *TriggerClock) Trigger() {
+	tc.triggers <- time.Now()
+}
+
+func (tc *TriggerClock) After(_ time.Duration) <-chan time.Time {
+	return tc.triggers
+}
+
+func (*TriggerClock) Now() time.Time {
+	return time.Now()
+}
+
 type RendererMock struct {
 	Renders []interface{}
 }
@@ -406,3 +429,67 @@ type NeverSleeper struct{}
 func (ns NeverSleeper) Reset()                  {}
 func (ns NeverSleeper) Sleep()                  {}
 func (ns NeverSleeper) Duration() time.Duration { return 0 * time.Microsecond }
+
+func (ns NeverSleeper) After() <-chan time.Time {
+	channel := make(chan time.Time, 1)
+	channel <- time.Now()
+	return channel
+}
+
+// TriggerSleeper only ends the waits returned by After when Trigger is
+// called.
+type TriggerSleeper struct {
+	triggers chan time.Time
+}
+
+func NewTriggerSleeper() *TriggerSleeper {
+	return &TriggerSleeper{triggers: make(chan time.Time)}
+}
+
+func (ts *TriggerSleeper) Trigger() {
+	ts.triggers <- time.Now()
+}
+
+// Triggers returns the channel Trigger sends on, for tests that must not
+// block if nothing is waiting.
+func (ts *TriggerSleeper) Triggers() chan<- time.Time {
+	return ts.triggers
+}
+
+func (*TriggerSleeper) Reset()                  {}
+func (*TriggerSleeper) Sleep()                  {}
+func (*TriggerSleeper) Duration() time.Duration { return 0 * time.Microsecond }
+
+func (ts *TriggerSleeper) After() <-chan time.Time {
+	return ts.triggers
+}
+
+type MockPublisher struct {
+	Events []store.Event
+	mutex  sync.Mutex
+}
+
+func UseMockPublisher(s *store.Store) *MockPublisher {
+	pub := &MockPublisher{}
+	s.Publisher = pub
+	return pub
+}
+
+func (mp *MockPublisher) Publish(event store.Event) error {
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+	mp.Events = append(mp.Events, event)
+	return nil
+}
+
+func (*MockPublisher) Close() error { return nil }
+
+func (mp *MockPublisher) Types() []string {
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+	types := []string{}
+	for _, e := range mp.Events {
+		types = append(types, e.Type)
+	}
+	return types
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"path"
+	"strings"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -46,24 +47,72 @@ func SetLogger(l *Logger) {
 	logger = l
 }
 
-// Reconfigure creates a new log file at the configured directory
-// with the given LogLevel.
-func Reconfigure(dir string, lvl zapcore.Level) {
-	config := generateConfig(dir)
+// Reconfigure replaces the logger with one at the given LogLevel, writing
+// entries in the given encoding ("json" or "console") to each of the sinks:
+// "stdout", "stderr", "syslog", or "file" for a log file in dir. An empty
+// encoding or list of sinks falls back to JSON on stderr and file.
+func Reconfigure(dir string, lvl zapcore.Level, encoding string, sinks []string) {
+	config, useSyslog, err := generateConfig(dir, encoding, sinks)
+	if err != nil {
+		log.Fatal(err)
+	}
 	config.Level.SetLevel(lvl)
-	zl, err := config.Build(zap.AddCallerSkip(1))
+	opts := []zap.Option{zap.AddCallerSkip(1)}
+	if useSyslog {
+		core, err := syslogCore(config)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(c, core)
+		}))
+	}
+	zl, err := config.Build(opts...)
 	if err != nil {
 		log.Fatal(err)
 	}
 	SetLogger(NewLogger(zl))
 }
 
-func generateConfig(dir string) zap.Config {
+func generateConfig(dir string, encoding string, sinks []string) (zap.Config, bool, error) {
 	config := zap.NewProductionConfig()
-	destination := path.Join(dir, "log.jsonl")
-	config.OutputPaths = []string{"stderr", destination}
-	config.ErrorOutputPaths = []string{"stderr", destination}
-	return config
+	switch encoding {
+	case "", "json":
+		config.Encoding = "json"
+	case "console":
+		config.Encoding = "console"
+	default:
+		return config, false, fmt.Errorf("unsupported log encoding %v", encoding)
+	}
+	if len(sinks) == 0 {
+		sinks = []string{"stderr", "file"}
+	}
+
+	useSyslog := false
+	paths := []string{}
+	for _, sink := range sinks {
+		switch strings.TrimSpace(sink) {
+		case "stdout", "stderr":
+			paths = append(paths, strings.TrimSpace(sink))
+		case "file":
+			paths = append(paths, path.Join(dir, logFileName(config.Encoding)))
+		case "syslog":
+			useSyslog = true
+		case "":
+		default:
+			return config, false, fmt.Errorf("unsupported log sink %v", sink)
+		}
+	}
+	config.OutputPaths = paths
+	config.ErrorOutputPaths = paths
+	return config, useSyslog, nil
+}
+
+func logFileName(encoding string) string {
+	if encoding == "console" {
+		return "log.txt"
+	}
+	return "log.jsonl"
 }
 
 // Infow logs an info message and any additional given information.
@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogger_GenerateConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		encoding     string
+		sinks        []string
+		wantEncoding string
+		wantPaths    []string
+		wantSyslog   bool
+		wantError    bool
+	}{
+		{"defaults", "", nil, "json", []string{"stderr", "/dir/log.jsonl"}, false, false},
+		{"json", "json", []string{"stdout"}, "json", []string{"stdout"}, false, false},
+		{"console file", "console", []string{"file"}, "console", []string{"/dir/log.txt"}, false, false},
+		{"spaces and blanks", "", []string{" stdout", "", "file "}, "json", []string{"stdout", "/dir/log.jsonl"}, false, false},
+		{"syslog", "", []string{"syslog", "stderr"}, "json", []string{"stderr"}, true, false},
+		{"unsupported encoding", "xml", nil, "", nil, false, true},
+		{"unsupported sink", "", []string{"stderr", "kafka"}, "", nil, false, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, useSyslog, err := generateConfig("/dir", test.encoding, test.sinks)
+			if test.wantError {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantEncoding, config.Encoding)
+			assert.Equal(t, test.wantPaths, config.OutputPaths)
+			assert.Equal(t, test.wantPaths, config.ErrorOutputPaths)
+			assert.Equal(t, test.wantSyslog, useSyslog)
+		})
+	}
+}
+
+func TestLogger_Reconfigure(t *testing.T) {
+	previous := logger
+	defer SetLogger(previous)
+
+	tests := []struct {
+		name     string
+		encoding string
+		file     string
+		check    func(t *testing.T, line string)
+	}{
+		{"json", "json", "log.jsonl", func(t *testing.T, line string) {
+			var entry map[string]interface{}
+			assert.Nil(t, json.Unmarshal([]byte(line), &entry))
+			assert.Equal(t, "reconfigured", entry["msg"])
+		}},
+		{"console", "console", "log.txt", func(t *testing.T, line string) {
+			assert.Contains(t, line, "reconfigured")
+			assert.False(t, strings.HasPrefix(line, "{"))
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "chainlink_logger")
+			assert.Nil(t, err)
+			defer os.RemoveAll(dir)
+
+			Reconfigure(dir, zapcore.InfoLevel, test.encoding, []string{"file"})
+			Debugw("filtered by level")
+			Info("reconfigured")
+			assert.Nil(t, Sync())
+
+			b, err := ioutil.ReadFile(path.Join(dir, test.file))
+			assert.Nil(t, err)
+			lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+			assert.Equal(t, 1, len(lines))
+			test.check(t, lines[0])
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// +build !windows
+
+package logger
+
+import (
+	"fmt"
+	"log/syslog"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func syslogCore(config zap.Config) (zapcore.Core, error) {
+	w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, "chainlink")
+	if err != nil {
+		return nil, fmt.Errorf("connecting to syslog: %v", err)
+	}
+	var encoder zapcore.Encoder
+	if config.Encoding == "console" {
+		encoder = zapcore.NewConsoleEncoder(config.EncoderConfig)
+	} else {
+		encoder = zapcore.NewJSONEncoder(config.EncoderConfig)
+	}
+	return zapcore.NewCore(encoder, zapcore.AddSync(w), config.Level), nil
+}
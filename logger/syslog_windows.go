@@ -0,0 +1,12 @@
+package logger
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func syslogCore(config zap.Config) (zapcore.Core, error) {
+	return nil, errors.New("the syslog sink is not supported on windows")
+}
@@ -18,10 +18,13 @@ type Application interface {
 // and Store. The EthereumListener and Scheduler are also available
 // in the services package, but the Store has its own package.
 type ChainlinkApplication struct {
-	HeadTracker  *HeadTracker
+	HeadTracker      *HeadTracker
 	EthereumListener *EthereumListener
-	Scheduler    *Scheduler
-	Store        *store.Store
+	ENSRefresher     *ENSRefresher
+	GasPriceUpdater  *GasPriceUpdater
+	HeadChecker      *HeadChecker
+	Scheduler        *Scheduler
+	Store            *store.Store
 }
 
 // NewApplication initializes a new store if one is not already
@@ -30,24 +33,32 @@ type ChainlinkApplication struct {
 // be used by the node.
 func NewApplication(config store.Config) Application {
 	store := store.NewStore(config)
-	logger.Reconfigure(config.RootDir, config.LogLevel.Level)
+	logger.Reconfigure(config.RootDir, config.LogLevel.Level, config.LogEncoding, config.LogSinks)
 	ht := NewHeadTracker(store)
+	el := &EthereumListener{Store: store, HeadTracker: ht}
 	return &ChainlinkApplication{
-		HeadTracker:  ht,
-		EthereumListener: &EthereumListener{Store: store, HeadTracker: ht},
-		Scheduler:    NewScheduler(store),
-		Store:        store,
+		HeadTracker:      ht,
+		EthereumListener: el,
+		ENSRefresher:     &ENSRefresher{Store: store, EthereumListener: el},
+		GasPriceUpdater:  &GasPriceUpdater{Store: store, HeadTracker: ht},
+		HeadChecker:      &HeadChecker{Store: store, HeadTracker: ht},
+		Scheduler:        NewScheduler(store),
+		Store:            store,
 	}
 }
 
-// Start runs the Store, EthereumListener, and Scheduler. If successful,
-// nil will be returned.
+// Start runs the Store, EthereumListener, and Scheduler, and resumes runs
+// left pending. If successful, nil will be returned.
 func (app *ChainlinkApplication) Start() error {
 	app.Store.Start()
 	return multierr.Combine(
 		app.HeadTracker.Start(),
 		app.EthereumListener.Start(),
-		app.Scheduler.Start())
+		app.ENSRefresher.Start(),
+		app.GasPriceUpdater.Start(),
+		app.HeadChecker.Start(),
+		app.Scheduler.Start(),
+		ResumePendingRuns(app.Store))
 }
 
 // Stop allows the application to exit by halting schedules, closing
@@ -56,6 +67,9 @@ func (app *ChainlinkApplication) Stop() error {
 	defer logger.Sync()
 	logger.Info("Gracefully exiting...")
 	app.Scheduler.Stop()
+	app.ENSRefresher.Stop()
+	app.GasPriceUpdater.Stop()
+	app.HeadChecker.Stop()
 	app.EthereumListener.Stop()
 	app.HeadTracker.Stop()
 	return app.Store.Close()
@@ -68,12 +82,16 @@ func (app *ChainlinkApplication) GetStore() *store.Store {
 
 // AddJob adds a job to the store and the scheduler. If there was
 // an error from adding the job to the store, the job will not be
-// added to the scheduler.
+// added to the scheduler. Any ENS names in the job's initiators are
+// resolved before it is saved.
 func (app *ChainlinkApplication) AddJob(job models.JobSpec) error {
-	err := app.Store.SaveJob(&job)
+	job, _, err := ResolveENSNames(job, app.Store)
 	if err != nil {
 		return err
 	}
+	if err = app.Store.SaveJob(&job); err != nil {
+		return err
+	}
 
 	app.Scheduler.AddJob(job)
 	return app.EthereumListener.AddJob(job)
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"go.uber.org/multierr"
+)
+
+// ResolveENSNames looks up the current address for every initiator of the
+// job that was given an ENS name. It returns a copy of the job with the
+// resolved addresses, and whether any of them differ from before.
+func ResolveENSNames(job models.JobSpec, store *store.Store) (models.JobSpec, bool, error) {
+	changed := false
+	initrs := make([]models.Initiator, len(job.Initiators))
+	copy(initrs, job.Initiators)
+	for i, initr := range initrs {
+		if initr.ENSName == "" {
+			continue
+		}
+		address, err := store.TxManager.ResolveENSName(initr.ENSName)
+		if err != nil {
+			return job, false, fmt.Errorf("resolving %v: %v", initr.ENSName, err)
+		}
+		if address != initr.Address {
+			initrs[i].Address = address
+			changed = true
+		}
+	}
+	job.Initiators = initrs
+	return job, changed, nil
+}
+
+// ENSRefresher periodically re-resolves the ENS names used by job
+// initiators, so that jobs follow a contract that has been redeployed
+// behind the same name.
+type ENSRefresher struct {
+	Store            *store.Store
+	EthereumListener *EthereumListener
+	done             chan struct{}
+}
+
+// Start begins re-resolving names at the configured ENS_REFRESH_INTERVAL.
+// A zero interval disables refreshing.
+func (er *ENSRefresher) Start() error {
+	interval := er.Store.Config.ENSRefreshInterval
+	if interval <= 0 {
+		return nil
+	}
+	done := make(chan struct{})
+	er.done = done
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-er.Store.Clock.After(interval):
+				logger.WarnIf(er.Refresh())
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop halts the periodic refresh.
+func (er *ENSRefresher) Stop() {
+	if er.done != nil {
+		close(er.done)
+		er.done = nil
+	}
+}
+
+// Refresh resolves the names of all jobs, saving and resubscribing those
+// whose initiators now point at a different address.
+func (er *ENSRefresher) Refresh() error {
+	jobs, err := er.Store.Jobs()
+	if err != nil {
+		return err
+	}
+	var merr error
+	for _, j := range jobs {
+		job, changed, err := ResolveENSNames(j, er.Store)
+		if err != nil {
+			merr = multierr.Append(merr, fmt.Errorf("ENSRefresher: Job#%v: %v", j.ID, err))
+			continue
+		} else if !changed {
+			continue
+		}
+
+		logger.Infow(fmt.Sprintf("ENS names for job %v resolved to new addresses", job.ID), "initiators", job.Initiators)
+		if err := er.Store.SaveJob(&job); err != nil {
+			merr = multierr.Append(merr, err)
+			continue
+		}
+		er.EthereumListener.RemoveJob(job.ID)
+		merr = multierr.Append(merr, er.EthereumListener.AddJob(job))
+	}
+	return merr
+}
@@ -0,0 +1,77 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveENSNames(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	eth := cltest.MockEthOnStore(store)
+
+	oldAddress := cltest.NewAddress()
+	newAddress := cltest.NewAddress()
+	j := cltest.NewJob()
+	j.Initiators = []models.Initiator{
+		{Type: models.InitiatorRunLog, Address: oldAddress, ENSName: "oracle.example.eth"},
+		{Type: models.InitiatorWeb},
+	}
+
+	eth.Register("eth_call", common.BytesToHash(cltest.NewAddress().Bytes()).Hex())
+	eth.Register("eth_call", common.BytesToHash(newAddress.Bytes()).Hex())
+
+	resolved, changed, err := services.ResolveENSNames(j, store)
+	assert.Nil(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, newAddress, resolved.Initiators[0].Address)
+	assert.Equal(t, oldAddress, j.Initiators[0].Address)
+	eth.EnsureAllCalled(t)
+}
+
+func TestResolveENSNames_Unchanged(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	eth := cltest.MockEthOnStore(store)
+
+	address := cltest.NewAddress()
+	j := cltest.NewJob()
+	j.Initiators = []models.Initiator{
+		{Type: models.InitiatorRunLog, Address: address, ENSName: "oracle.example.eth"},
+	}
+
+	eth.Register("eth_call", common.BytesToHash(cltest.NewAddress().Bytes()).Hex())
+	eth.Register("eth_call", common.BytesToHash(address.Bytes()).Hex())
+
+	_, changed, err := services.ResolveENSNames(j, store)
+	assert.Nil(t, err)
+	assert.False(t, changed)
+	eth.EnsureAllCalled(t)
+}
+
+func TestResolveENSNames_Error(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	eth := cltest.MockEthOnStore(store)
+
+	j := cltest.NewJob()
+	j.Initiators = []models.Initiator{
+		{Type: models.InitiatorRunLog, ENSName: "missing.eth"},
+	}
+	eth.RegisterError("eth_call", "execution error")
+
+	_, _, err := services.ResolveENSNames(j, store)
+	assert.NotNil(t, err)
+	eth.EnsureAllCalled(t)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/asdine/storm"
 	uuid "github.com/satori/go.uuid"
@@ -47,12 +48,28 @@ func (el *EthereumListener) AddJob(job models.JobSpec) error {
 
 	sub, err := StartJobSubscription(job, el.HeadTracker.Get(), el.Store)
 	if err != nil {
+		recordJobError(el.Store, job.ID, fmt.Sprintf("Unable to subscribe to logs: %v", err))
 		return err
 	}
 	el.addSubscription(sub)
 	return nil
 }
 
+// RemoveJob unsubscribes from the logs being watched for the given job.
+func (el *EthereumListener) RemoveJob(jobID string) {
+	el.jobsMutex.Lock()
+	defer el.jobsMutex.Unlock()
+	remaining := []JobSubscription{}
+	for _, sub := range el.jobSubscriptions {
+		if sub.Job.ID == jobID {
+			sub.Unsubscribe()
+		} else {
+			remaining = append(remaining, sub)
+		}
+	}
+	el.jobSubscriptions = remaining
+}
+
 func (el *EthereumListener) Jobs() []models.JobSpec {
 	var jobs []models.JobSpec
 	for _, js := range el.jobSubscriptions {
@@ -87,15 +104,18 @@ func (el *EthereumListener) Disconnect() {
 	el.jobSubscriptions = []JobSubscription{}
 }
 
+// OnNewHead resumes the pending runs that are waiting on a new block. Runs
+// pending before wake conditions were recorded are resumed too.
 func (el *EthereumListener) OnNewHead(_ *models.BlockHeader) {
 	pendingRuns, err := el.Store.PendingJobRuns()
 	if err != nil {
 		logger.Error(err.Error())
 	}
 	for _, jr := range pendingRuns {
-		if _, err := ExecuteRun(jr, el.Store, models.RunResult{}); err != nil {
-			logger.Error(err.Error())
+		if jr.WakeOn != models.WakeOnHead && jr.WakeOn != "" {
+			continue
 		}
+		WakeRun(jr, el.Store)
 	}
 }
 
@@ -123,7 +143,13 @@ type HeadTracker struct {
 	headMutex        sync.RWMutex
 	trackersMutex    sync.RWMutex
 	connected        bool
+	connectedMutex   sync.RWMutex
 	sleeper          utils.Sleeper
+	done             chan struct{}
+	reconnecting     bool
+	connectionMutex  sync.Mutex
+	health           error
+	healthMutex      sync.RWMutex
 }
 
 // Instantiates a new HeadTracker using the orm to persist new block numbers
@@ -137,28 +163,67 @@ func NewHeadTracker(store *store.Store, sleepers ...utils.Sleeper) *HeadTracker
 	return &HeadTracker{store: store, trackers: map[string]HeadTrackable{}, sleeper: sleeper}
 }
 
+// Start subscribes to new heads, reconnecting whenever the subscription is
+// lost until Stop is called.
 func (ht *HeadTracker) Start() error {
+	done := make(chan struct{})
+	ht.connectionMutex.Lock()
+	ht.done = done
+	ht.connectionMutex.Unlock()
+	return ht.connect(done)
+}
+
+// Stop unsubscribes from new heads and halts any attempt to reconnect.
+func (ht *HeadTracker) Stop() error {
+	ht.connectionMutex.Lock()
+	if ht.done != nil {
+		close(ht.done)
+		ht.done = nil
+	}
+	ht.connectionMutex.Unlock()
+	return ht.disconnect()
+}
+
+// connect subscribes to new heads unless done has been closed. The
+// connection state is only changed with connectionMutex held, so that a
+// connection made while stopping is either seen and undone by Stop or not
+// made at all.
+func (ht *HeadTracker) connect(done chan struct{}) error {
 	numbers := []models.IndexableBlockNumber{}
 	err := ht.store.Select().OrderBy("Digits", "Number").Limit(1).Reverse().Find(&numbers)
 	if err != nil && err != storm.ErrNotFound {
 		return err
 	}
 	if len(numbers) > 0 {
+		ht.headMutex.Lock()
 		ht.number = &numbers[0]
+		ht.headMutex.Unlock()
 	}
 
-	ht.headers = make(chan models.BlockHeader)
-	sub, err := ht.subscribeToNewHeads()
+	ht.detectClientType()
+	ht.connectionMutex.Lock()
+	defer ht.connectionMutex.Unlock()
+	select {
+	case <-done:
+		return errors.New("HeadTracker stopped")
+	default:
+	}
+	headers := make(chan models.BlockHeader)
+	sub, err := ht.subscribeToNewHeads(headers, done)
 	if err != nil {
 		return err
 	}
+	ht.headers = headers
 	ht.headSubscription = sub
+	ht.reconnecting = false
 	ht.Connect()
-	go ht.listenToNewHeads()
+	go ht.listenToNewHeads(headers, done)
 	return nil
 }
 
-func (ht *HeadTracker) Stop() error {
+func (ht *HeadTracker) disconnect() error {
+	ht.connectionMutex.Lock()
+	defer ht.connectionMutex.Unlock()
 	if ht.headSubscription != nil && ht.headSubscription.Err() != nil {
 		ht.headSubscription.Unsubscribe()
 		ht.headSubscription = nil
@@ -171,6 +236,23 @@ func (ht *HeadTracker) Stop() error {
 	return nil
 }
 
+// reconnect disconnects and runs the reconnect loop, unless a loop is
+// already running, so that a subscription error and a head timeout for the
+// same outage do not reconnect twice. The loop is marked as finished by the
+// connection it makes, so that losing that connection reconnects again.
+func (ht *HeadTracker) reconnect(done chan struct{}) {
+	ht.connectionMutex.Lock()
+	if ht.reconnecting {
+		ht.connectionMutex.Unlock()
+		return
+	}
+	ht.reconnecting = true
+	ht.connectionMutex.Unlock()
+
+	ht.disconnect()
+	ht.reconnectLoop(done)
+}
+
 // Updates the latest block number, if indeed the latest, and persists
 // this number in case of reboot. Thread safe.
 func (ht *HeadTracker) Save(n *models.IndexableBlockNumber) error {
@@ -199,7 +281,7 @@ func (ht *HeadTracker) Attach(t HeadTrackable) string {
 	defer ht.trackersMutex.Unlock()
 	id := uuid.Must(uuid.NewV4()).String()
 	ht.trackers[id] = t
-	if ht.connected {
+	if ht.IsConnected() {
 		t.Connect()
 	}
 	return id
@@ -209,27 +291,40 @@ func (ht *HeadTracker) Detach(id string) {
 	ht.trackersMutex.Lock()
 	defer ht.trackersMutex.Unlock()
 	t, present := ht.trackers[id]
-	if ht.connected && present {
+	if ht.IsConnected() && present {
 		t.Disconnect()
 	}
 	delete(ht.trackers, id)
 }
 
-func (ht *HeadTracker) IsConnected() bool { return ht.connected }
+// IsConnected returns true while subscribed to new heads. It is guarded
+// separately from the trackers, as trackers check it from their Connect
+// callbacks.
+func (ht *HeadTracker) IsConnected() bool {
+	ht.connectedMutex.RLock()
+	defer ht.connectedMutex.RUnlock()
+	return ht.connected
+}
+
+func (ht *HeadTracker) setConnected(connected bool) {
+	ht.connectedMutex.Lock()
+	defer ht.connectedMutex.Unlock()
+	ht.connected = connected
+}
 
 func (ht *HeadTracker) Connect() {
+	ht.setConnected(true)
 	ht.trackersMutex.RLock()
 	defer ht.trackersMutex.RUnlock()
-	ht.connected = true
 	for _, t := range ht.trackers {
 		logger.WarnIf(t.Connect())
 	}
 }
 
 func (ht *HeadTracker) Disconnect() {
+	ht.setConnected(false)
 	ht.trackersMutex.RLock()
 	defer ht.trackersMutex.RUnlock()
-	ht.connected = false
 	for _, t := range ht.trackers {
 		t.Disconnect()
 	}
@@ -243,8 +338,17 @@ func (ht *HeadTracker) OnNewHead(head *models.BlockHeader) {
 	}
 }
 
-func (ht *HeadTracker) subscribeToNewHeads() (models.EthSubscription, error) {
-	sub, err := ht.store.TxManager.SubscribeToNewHeads(ht.headers)
+func (ht *HeadTracker) detectClientType() {
+	ct, err := ht.store.TxManager.DetectClientType()
+	if err != nil {
+		logger.Warnw("Unable to detect Ethereum client, using default subscription parameters", "err", err)
+		return
+	}
+	logger.Infow(fmt.Sprintf("Connected to %v client", ct), "url", ht.store.Config.EthereumURL)
+}
+
+func (ht *HeadTracker) subscribeToNewHeads(headers chan models.BlockHeader, done chan struct{}) (models.EthSubscription, error) {
+	sub, err := ht.store.TxManager.SubscribeToNewHeads(headers)
 	if err != nil {
 		return nil, err
 	}
@@ -252,40 +356,131 @@ func (ht *HeadTracker) subscribeToNewHeads() (models.EthSubscription, error) {
 		err := <-sub.Err()
 		if err != nil {
 			logger.Warnw("Error in new head subscription, disconnected", "err", err)
-			ht.Stop()
-			ht.reconnectLoop()
+			ht.reconnect(done)
 		}
 	}()
 	return sub, nil
 }
 
-func (ht *HeadTracker) listenToNewHeads() {
-	if ht.number != nil {
-		logger.Info("Tracking logs from block ", ht.number.FriendlyString(), " with hash ", ht.number.Hash.String())
+func (ht *HeadTracker) listenToNewHeads(headers <-chan models.BlockHeader, done chan struct{}) {
+	if number := ht.Get(); number != nil {
+		logger.Info("Tracking logs from block ", number.FriendlyString(), " with hash ", number.Hash.String())
 	}
-	for header := range ht.headers {
-		number := header.IndexableBlockNumber()
-		logger.Debugw(fmt.Sprintf("Received header %v", number.FriendlyString()), "hash", header.Hash())
-		if err := ht.Save(number); err != nil {
-			logger.Error(err.Error())
-		} else {
-			ht.OnNewHead(&header)
+	ct := ht.store.TxManager.ClientType()
+	for {
+		select {
+		case header, open := <-headers:
+			if !open {
+				return
+			}
+			ht.receiveHeader(header, ct)
+		case <-ht.headTimeout():
+			logger.Warnw(fmt.Sprintf("No new heads received in %v, reconnecting", ht.store.Config.EthHeadTimeout), "url", ht.store.Config.EthereumURL)
+			ht.reconnect(done)
+			return
 		}
 	}
 }
 
-func (ht *HeadTracker) reconnectLoop() {
+func (ht *HeadTracker) receiveHeader(header models.BlockHeader, ct models.ClientType) {
+	number := header.IndexableBlockNumberFor(ct)
+	logger.Debugw(fmt.Sprintf("Received header %v", number.FriendlyString()), "hash", number.Hash)
+	ht.recordSample(header)
+	if err := ht.Save(number); err != nil {
+		logger.Error(err.Error())
+	} else {
+		ht.store.Publish(store.EventNewHead, header)
+		ht.OnNewHead(&header)
+	}
+}
+
+// recordSample keeps when the header was received, as one of the last
+// ETH_HEAD_HISTORY samples.
+func (ht *HeadTracker) recordSample(header models.BlockHeader) {
+	keep := ht.store.Config.EthHeadHistory
+	if keep <= 0 {
+		return
+	}
+	sample := models.NewHeadSample(header, ht.store.Clock.Now())
+	if err := ht.store.SaveHeadSample(&sample, keep); err != nil {
+		logger.Warnw("Unable to record head sample", "err", err)
+	}
+}
+
+// headTimeout fires once ETH_HEAD_TIMEOUT passes without a new head, which
+// catches a connection that has silently died without erroring the
+// subscription. A zero timeout never fires.
+func (ht *HeadTracker) headTimeout() <-chan time.Time {
+	timeout := ht.store.Config.EthHeadTimeout
+	if timeout <= 0 {
+		return nil
+	}
+	return ht.store.Clock.After(timeout)
+}
+
+// Health returns the reason the tracker is unhealthy, which is once it has
+// failed to reconnect ETH_RECONNECT_ALERT_AFTER times in a row, or has
+// given up reconnecting, or nil while it is healthy.
+func (ht *HeadTracker) Health() error {
+	ht.healthMutex.RLock()
+	defer ht.healthMutex.RUnlock()
+	return ht.health
+}
+
+func (ht *HeadTracker) setHealth(err error) {
+	ht.healthMutex.Lock()
+	defer ht.healthMutex.Unlock()
+	ht.health = err
+}
+
+// Reconnection describes the attempts made to reconnect to ETH_URL, and is
+// the data of head.disconnected and head.reconnected events.
+type Reconnection struct {
+	URL      string `json:"url"`
+	Attempts int    `json:"attempts"`
+}
+
+// reconnectLoop resubscribes to new heads with backoff until it succeeds,
+// done is closed, or ETH_RECONNECT_MAX_ATTEMPTS attempts have failed. A
+// head.disconnected event is published once ETH_RECONNECT_ALERT_AFTER
+// attempts have failed, and a head.reconnected event once it succeeds.
+func (ht *HeadTracker) reconnectLoop(done chan struct{}) {
+	config := ht.store.Config
 	ht.sleeper.Reset()
-	for {
-		logger.Info("Reconnecting to node ", ht.store.Config.EthereumURL, " in ", ht.sleeper.Duration())
-		ht.sleeper.Sleep()
-		err := ht.Start()
-		if err != nil {
-			logger.Warnw(fmt.Sprintf("Error reconnecting to %v", ht.store.Config.EthereumURL), "err", err)
-			ht.Stop()
-		} else {
-			logger.Info("Reconnected to node ", ht.store.Config.EthereumURL)
-			break
+	for attempt := 1; ; attempt++ {
+		logger.Info("Reconnecting to node ", config.EthereumURL, " in ", ht.sleeper.Duration())
+		select {
+		case <-done:
+			logger.Info("Stopped reconnecting to node ", config.EthereumURL)
+			return
+		case <-ht.sleeper.After():
+		}
+
+		err := ht.connect(done)
+		if err == nil {
+			select {
+			case <-done:
+				ht.disconnect()
+				return
+			default:
+			}
+			logger.Info("Reconnected to node ", config.EthereumURL)
+			ht.setHealth(nil)
+			ht.store.Publish(store.EventHeadReconnected, Reconnection{URL: config.EthereumURL, Attempts: attempt})
+			return
+		}
+		logger.Warnw(fmt.Sprintf("Error reconnecting to %v", config.EthereumURL), "err", err, "attempt", attempt)
+		ht.disconnect()
+
+		if attempt == config.EthReconnectAlertAfter {
+			logger.Errorw(fmt.Sprintf("Unable to reconnect to %v after %v attempts", config.EthereumURL, attempt), "err", err)
+			ht.setHealth(fmt.Errorf("Unable to reconnect to %v after %v attempts: %v", config.EthereumURL, attempt, err))
+			ht.store.Publish(store.EventHeadDisconnected, Reconnection{URL: config.EthereumURL, Attempts: attempt})
+		}
+		if attempt == config.EthReconnectMaxAttempts {
+			logger.Errorw(fmt.Sprintf("Gave up reconnecting to %v after %v attempts", config.EthereumURL, attempt), "err", err)
+			ht.setHealth(fmt.Errorf("Gave up reconnecting to %v after %v attempts: %v", config.EthereumURL, attempt, err))
+			return
 		}
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -36,6 +37,46 @@ func TestEthereumListener_Start_WithJobs(t *testing.T) {
 	eth.EnsureAllCalled(t)
 }
 
+func TestEthereumListener_LogJobs_StartAndReconnect(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	eth := cltest.MockEthOnStore(store)
+	j := cltest.NewJobWithLogInitiator()
+	assert.Nil(t, store.SaveJob(&j))
+
+	firstSub := eth.RegisterSubscription("newHeads", make(chan models.BlockHeader))
+	eth.RegisterSubscription("logs")
+	ht := services.NewHeadTracker(store, cltest.NeverSleeper{})
+	el := services.EthereumListener{Store: store, HeadTracker: ht}
+
+	started := make(chan struct{})
+	go func() {
+		defer close(started)
+		assert.Nil(t, ht.Start())
+		assert.Nil(t, el.Start())
+	}()
+	g.Eventually(started).Should(gomega.BeClosed())
+	assert.Equal(t, 1, len(el.Jobs()))
+
+	eth.RegisterSubscription("newHeads", make(chan models.BlockHeader))
+	eth.RegisterSubscription("logs")
+	firstSub.Errors <- errors.New("Test error to force reconnect")
+	eth.EnsureAllCalled(t)
+	g.Eventually(func() int { return len(el.Jobs()) }).Should(gomega.Equal(1))
+	assert.True(t, ht.IsConnected())
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		assert.Nil(t, el.Stop())
+		assert.Nil(t, ht.Stop())
+	}()
+	g.Eventually(stopped).Should(gomega.BeClosed())
+}
+
 func newAddr() common.Address {
 	return cltest.NewAddress()
 }
@@ -204,6 +245,45 @@ func TestEthereumListener_newHeadsNotification(t *testing.T) {
 	assert.Equal(t, blockNumber, app.EthereumListener.HeadTracker.Get().Number)
 }
 
+func TestEthereumListener_OnNewHead_SkipsRunsNotWaitingOnHeads(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	store.Clock = cltest.NeverClock{}
+
+	j := models.NewJob()
+	j.Tasks = []models.TaskSpec{cltest.NewTask("NoOp")}
+	assert.Nil(t, store.SaveJob(&j))
+
+	tests := []struct {
+		wakeOn     string
+		wantStatus string
+	}{
+		{models.WakeOnHead, models.StatusCompleted},
+		{"", models.StatusCompleted},
+		{models.WakeOnCallback, models.StatusPending},
+		{models.WakeAtTime, models.StatusPending},
+	}
+
+	runs := []models.JobRun{}
+	for _, test := range tests {
+		jr := j.NewRun()
+		jr.Status = models.StatusPending
+		jr.WakeOn = test.wakeOn
+		assert.Nil(t, store.Save(&jr))
+		runs = append(runs, jr)
+	}
+
+	el := services.EthereumListener{Store: store}
+	el.OnNewHead(&models.BlockHeader{})
+
+	for i, test := range tests {
+		jr, err := store.FindJobRun(runs[i].ID)
+		assert.Nil(t, err)
+		assert.Equal(t, test.wantStatus, jr.Status, "wakeOn %q", test.wakeOn)
+	}
+}
+
 func TestHeadTracker_New(t *testing.T) {
 	t.Parallel()
 
@@ -344,3 +424,198 @@ func TestHeadTracker_ReconnectOnError(t *testing.T) {
 	assert.Equal(t, 2, checker.ConnectedCount)
 	assert.Equal(t, 1, checker.DisconnectedCount)
 }
+
+func TestHeadTracker_ReconnectOnHeadTimeout(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	config, cfgCleanup := cltest.NewConfig()
+	defer cfgCleanup()
+	config.EthHeadTimeout = time.Minute
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+	clock := cltest.NewTriggerClock()
+	store.Clock = clock
+	eth := cltest.MockEthOnStore(store)
+	ht := services.NewHeadTracker(store, cltest.NeverSleeper{})
+
+	firstHeaders := make(chan models.BlockHeader)
+	eth.RegisterSubscription("newHeads", firstHeaders)
+	headers := make(chan models.BlockHeader)
+	eth.RegisterSubscription("newHeads", headers)
+
+	checker := &cltest.MockHeadTrackable{}
+	ht.Attach(checker)
+	assert.Nil(t, ht.Start())
+
+	firstHeaders <- models.BlockHeader{Number: cltest.BigHexInt(1)}
+	g.Eventually(func() int { return checker.OnNewHeadCount }).Should(gomega.Equal(1))
+	assert.Equal(t, 1, checker.ConnectedCount)
+
+	// silence on the first subscription
+	clock.Trigger()
+	g.Eventually(func() int { return checker.ConnectedCount }).Should(gomega.Equal(2))
+	assert.Equal(t, 1, checker.DisconnectedCount)
+
+	headers <- models.BlockHeader{Number: cltest.BigHexInt(2)}
+	g.Eventually(func() int { return checker.OnNewHeadCount }).Should(gomega.Equal(2))
+}
+
+func TestHeadTracker_RecordsHeadSamples(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	config, cfgCleanup := cltest.NewConfig()
+	defer cfgCleanup()
+	config.EthHeadHistory = 2
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+	clock := cltest.UseSettableClock(store)
+	eth := cltest.MockEthOnStore(store)
+	ht := services.NewHeadTracker(store, cltest.NeverSleeper{})
+	defer ht.Stop()
+
+	checker := &cltest.MockHeadTrackable{}
+	ht.Attach(checker)
+	headers := make(chan models.BlockHeader)
+	eth.RegisterSubscription("newHeads", headers)
+	assert.Nil(t, ht.Start())
+
+	mined := time.Unix(1500000000, 0)
+	for i := int64(1); i <= 3; i++ {
+		clock.SetTime(mined.Add(time.Duration(i) * 15 * time.Second).Add(time.Duration(i) * time.Second))
+		headers <- models.BlockHeader{
+			Number: cltest.BigHexInt(i),
+			Time:   cltest.BigHexInt(mined.Unix() + i*15),
+		}
+		g.Eventually(func() int { return checker.OnNewHeadCount }).Should(gomega.Equal(int(i)))
+	}
+
+	samples, err := store.HeadSamples()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(samples))
+	assert.Equal(t, uint64(2), samples[0].Number)
+	assert.Equal(t, uint64(3), samples[1].Number)
+	assert.Equal(t, 3*time.Second, samples[1].ReceivedAt.Sub(samples[1].BlockTime))
+}
+
+func TestHeadTracker_ReconnectAlertsAndRestores(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	config, cfgCleanup := cltest.NewConfig()
+	defer cfgCleanup()
+	config.EthReconnectAlertAfter = 2
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+	pub := cltest.UseMockPublisher(store)
+	eth := cltest.MockEthOnStore(store)
+	sleeper := cltest.NewTriggerSleeper()
+	ht := services.NewHeadTracker(store, sleeper)
+	defer ht.Stop()
+
+	checker := &cltest.MockHeadTrackable{}
+	ht.Attach(checker)
+	firstSub := eth.RegisterSubscription("newHeads", make(chan models.BlockHeader))
+	assert.Nil(t, ht.Start())
+
+	firstSub.Errors <- errors.New("Test error to force reconnect")
+	sleeper.Trigger()
+	assert.Nil(t, ht.Health())
+	sleeper.Trigger()
+	g.Eventually(pub.Types).Should(gomega.Equal([]string{strpkg.EventHeadDisconnected}))
+	assert.NotNil(t, ht.Health())
+
+	eth.RegisterSubscription("newHeads", make(chan models.BlockHeader))
+	sleeper.Trigger()
+	g.Eventually(ht.Health).Should(gomega.BeNil())
+	g.Eventually(pub.Types).Should(gomega.Equal([]string{
+		strpkg.EventHeadDisconnected,
+		strpkg.EventHeadReconnected,
+	}))
+	assert.Equal(t, 2, checker.ConnectedCount)
+}
+
+func TestHeadTracker_ReconnectGivesUp(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	config, cfgCleanup := cltest.NewConfig()
+	defer cfgCleanup()
+	config.EthReconnectMaxAttempts = 2
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+	eth := cltest.MockEthOnStore(store)
+	ht := services.NewHeadTracker(store, cltest.NeverSleeper{})
+	defer ht.Stop()
+
+	firstSub := eth.RegisterSubscription("newHeads", make(chan models.BlockHeader))
+	assert.Nil(t, ht.Start())
+
+	firstSub.Errors <- errors.New("Test error to force reconnect")
+	g.Eventually(ht.Health).ShouldNot(gomega.BeNil())
+	assert.Contains(t, ht.Health().Error(), "Gave up reconnecting")
+}
+
+func TestHeadTracker_StopHaltsReconnect(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	config, cfgCleanup := cltest.NewConfig()
+	defer cfgCleanup()
+	config.EthReconnectAlertAfter = 1
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+	eth := cltest.MockEthOnStore(store)
+	sleeper := cltest.NewTriggerSleeper()
+	ht := services.NewHeadTracker(store, sleeper)
+
+	firstSub := eth.RegisterSubscription("newHeads", make(chan models.BlockHeader))
+	assert.Nil(t, ht.Start())
+	firstSub.Errors <- errors.New("Test error to force reconnect")
+	sleeper.Trigger()
+	g.Eventually(ht.Health).ShouldNot(gomega.BeNil())
+
+	assert.Nil(t, ht.Stop())
+	triggered := make(chan struct{})
+	go func() {
+		sleeper.Trigger()
+		close(triggered)
+	}()
+	select {
+	case <-triggered:
+		t.Fatal("Reconnect loop still running after Stop")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestHeadTracker_StopDuringReconnect(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	eth := cltest.MockEthOnStore(store)
+	sleeper := cltest.NewTriggerSleeper()
+	ht := services.NewHeadTracker(store, sleeper)
+
+	firstSub := eth.RegisterSubscription("newHeads", make(chan models.BlockHeader))
+	eth.RegisterSubscription("newHeads", make(chan models.BlockHeader))
+	assert.Nil(t, ht.Start())
+	firstSub.Errors <- errors.New("Test error to force reconnect")
+	g.Eventually(ht.IsConnected).Should(gomega.BeFalse())
+
+	triggered := make(chan struct{})
+	go func() {
+		defer close(triggered)
+		select {
+		case sleeper.Triggers() <- time.Now():
+		case <-time.After(time.Second):
+		}
+	}()
+	assert.Nil(t, ht.Stop())
+	<-triggered
+
+	g.Eventually(ht.IsConnected).Should(gomega.BeFalse())
+	g.Consistently(ht.IsConnected).Should(gomega.BeFalse())
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// GasPriceUpdater refreshes the TxManager's gas price from the node when
+// connecting, and then every ETH_GAS_PRICE_REFRESH_BLOCKS heads.
+type GasPriceUpdater struct {
+	Store         *store.Store
+	HeadTracker   *HeadTracker
+	headTrackerId string
+	heads         uint64
+}
+
+// Start attaches to the HeadTracker, unless refreshing has been disabled by
+// setting ETH_GAS_PRICE_REFRESH_BLOCKS to zero.
+func (gpu *GasPriceUpdater) Start() error {
+	if gpu.Store.Config.EthGasPriceRefreshBlocks == 0 {
+		return nil
+	}
+	gpu.headTrackerId = gpu.HeadTracker.Attach(gpu)
+	return nil
+}
+
+// Stop detaches from the HeadTracker.
+func (gpu *GasPriceUpdater) Stop() error {
+	if gpu.headTrackerId != "" {
+		gpu.HeadTracker.Detach(gpu.headTrackerId)
+		gpu.headTrackerId = ""
+	}
+	return nil
+}
+
+// Connect refreshes the gas price as soon as the node is reachable.
+func (gpu *GasPriceUpdater) Connect() error {
+	gpu.heads = 0
+	return gpu.Store.TxManager.RefreshGasPrice()
+}
+
+// Disconnect is a no op, the last known gas price is kept.
+func (gpu *GasPriceUpdater) Disconnect() {}
+
+// OnNewHead refreshes the gas price every ETH_GAS_PRICE_REFRESH_BLOCKS heads.
+func (gpu *GasPriceUpdater) OnNewHead(*models.BlockHeader) {
+	gpu.heads++
+	if gpu.heads%gpu.Store.Config.EthGasPriceRefreshBlocks == 0 {
+		logger.WarnIf(gpu.Store.TxManager.RefreshGasPrice())
+	}
+}
@@ -0,0 +1,52 @@
+package services_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGasPriceUpdater_OnNewHead(t *testing.T) {
+	t.Parallel()
+
+	config, cfgCleanup := cltest.NewConfig()
+	defer cfgCleanup()
+	config.EthGasPriceRefreshBlocks = 2
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+	eth := cltest.MockEthOnStore(store)
+
+	gpu := &services.GasPriceUpdater{Store: store}
+
+	eth.Register("eth_gasPrice", "0x3b9aca00")
+	assert.Nil(t, gpu.Connect())
+	assert.Equal(t, big.NewInt(1000000000), store.TxManager.GasPrice())
+
+	gpu.OnNewHead(&models.BlockHeader{})
+	assert.Equal(t, big.NewInt(1000000000), store.TxManager.GasPrice())
+
+	eth.Register("eth_gasPrice", "0x77359400")
+	gpu.OnNewHead(&models.BlockHeader{})
+	assert.Equal(t, big.NewInt(2000000000), store.TxManager.GasPrice())
+	eth.EnsureAllCalled(t)
+}
+
+func TestGasPriceUpdater_Start_Disabled(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	cltest.MockEthOnStore(store)
+	ht := services.NewHeadTracker(store)
+	assert.Nil(t, ht.Start())
+	defer ht.Stop()
+
+	gpu := &services.GasPriceUpdater{Store: store, HeadTracker: ht}
+	assert.Nil(t, gpu.Start())
+	assert.Nil(t, gpu.Stop())
+	assert.Equal(t, &store.Config.EthGasPriceDefault, store.TxManager.GasPrice())
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/store"
+)
+
+// HeadLag describes how far the HeadTracker's latest head is behind the
+// block number reported by the node's eth_blockNumber.
+type HeadLag struct {
+	Head        *big.Int `json:"head"`
+	BlockNumber *big.Int `json:"blockNumber"`
+	Lag         int64    `json:"lag"`
+}
+
+// HeadChecker periodically compares the latest head received through the
+// HeadTracker's subscription with eth_blockNumber, to catch providers whose
+// websocket feed falls behind their RPC API.
+type HeadChecker struct {
+	Store       *store.Store
+	HeadTracker *HeadTracker
+	done        chan struct{}
+	lagging     bool
+}
+
+// Start begins checking at the configured ETH_HEAD_CHECK_INTERVAL. A zero
+// interval disables checking.
+func (hc *HeadChecker) Start() error {
+	interval := hc.Store.Config.EthHeadCheckInterval
+	if interval <= 0 {
+		return nil
+	}
+	done := make(chan struct{})
+	hc.done = done
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-hc.Store.Clock.After(interval):
+				if _, err := hc.Check(); err != nil {
+					logger.Warnw("Unable to check head against eth_blockNumber", "err", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop halts the periodic check.
+func (hc *HeadChecker) Stop() {
+	if hc.done != nil {
+		close(hc.done)
+		hc.done = nil
+	}
+}
+
+// Check compares the latest head with eth_blockNumber, and warns and
+// publishes a head.lagging event if it is more than ETH_HEAD_MAX_LAG blocks
+// behind. Nothing is checked until a first head has been received.
+func (hc *HeadChecker) Check() (HeadLag, error) {
+	head := hc.HeadTracker.Get()
+	if head == nil {
+		return HeadLag{}, nil
+	}
+	number, err := hc.Store.TxManager.GetBlockNumber()
+	if err != nil {
+		return HeadLag{}, err
+	}
+
+	blockNumber := new(big.Int).SetUint64(number)
+	lag := HeadLag{
+		Head:        head.ToInt(),
+		BlockNumber: blockNumber,
+		Lag:         new(big.Int).Sub(blockNumber, head.ToInt()).Int64(),
+	}
+	if lag.Lag > int64(hc.Store.Config.EthHeadMaxLag) {
+		hc.lagging = true
+		logger.Warnw(
+			fmt.Sprintf("Head subscription is %v blocks behind eth_blockNumber", lag.Lag),
+			"head", lag.Head, "blockNumber", lag.BlockNumber,
+		)
+		hc.Store.Publish(store.EventHeadLagging, lag)
+	} else if hc.lagging {
+		hc.lagging = false
+		logger.Infow("Head subscription caught up with eth_blockNumber", "head", lag.Head, "blockNumber", lag.BlockNumber)
+	}
+	return lag, nil
+}
@@ -0,0 +1,72 @@
+package services_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/services"
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeadChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		blockNumber string
+		wantLag     int64
+		wantEvent   bool
+	}{
+		{"in step", "0x64", 0, false},
+		{"within threshold", "0x69", 5, false},
+		{"lagging", "0x6a", 6, true},
+		{"ahead of rpc", "0x60", -4, false},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			config, cfgCleanup := cltest.NewConfig()
+			defer cfgCleanup()
+			config.EthHeadMaxLag = 5
+			store, cleanup := cltest.NewStoreWithConfig(config)
+			defer cleanup()
+			eth := cltest.MockEthOnStore(store)
+			pub := cltest.UseMockPublisher(store)
+
+			ht := services.NewHeadTracker(store)
+			assert.Nil(t, ht.Save(&models.IndexableBlockNumber{Number: hexutil.Big(*big.NewInt(100))}))
+			hc := &services.HeadChecker{Store: store, HeadTracker: ht}
+
+			eth.Register("eth_blockNumber", test.blockNumber)
+			lag, err := hc.Check()
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantLag, lag.Lag)
+			assert.Equal(t, big.NewInt(100), lag.Head)
+			if test.wantEvent {
+				assert.Equal(t, []string{strpkg.EventHeadLagging}, pub.Types())
+			} else {
+				assert.Empty(t, pub.Types())
+			}
+			eth.EnsureAllCalled(t)
+		})
+	}
+}
+
+func TestHeadChecker_Check_NoHead(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	eth := cltest.MockEthOnStore(store)
+
+	hc := &services.HeadChecker{Store: store, HeadTracker: services.NewHeadTracker(store)}
+	lag, err := hc.Check()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), lag.Lag)
+	eth.EnsureAllCalled(t)
+}
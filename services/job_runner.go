@@ -1,18 +1,20 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/smartcontractkit/chainlink/adapters"
 	"github.com/smartcontractkit/chainlink/logger"
-	"github.com/smartcontractkit/chainlink/store"
+	strpkg "github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
 	null "gopkg.in/guregu/null.v3"
 )
 
 // BeginRun creates a new run if the job is valid and starts the job.
-func BeginRun(job models.JobSpec, store *store.Store, input models.RunResult) (models.JobRun, error) {
+func BeginRun(job models.JobSpec, store *strpkg.Store, input models.RunResult) (models.JobRun, error) {
 	run, err := BuildRun(job, store)
 	if err != nil {
 		return models.JobRun{}, err
@@ -22,16 +24,18 @@ func BeginRun(job models.JobSpec, store *store.Store, input models.RunResult) (m
 
 // BuildRun checks to ensure the given job has not started or ended before
 // creating a new run for the job.
-func BuildRun(job models.JobSpec, store *store.Store) (models.JobRun, error) {
+func BuildRun(job models.JobSpec, store *strpkg.Store) (models.JobRun, error) {
 	now := store.Clock.Now()
 	if !job.Started(now) {
 		return models.JobRun{}, JobRunnerError{
-			msg: fmt.Sprintf("Job runner: Job %v unstarted: %v before job's start time %v", job.ID, now, job.EndAt),
+			msg:    fmt.Sprintf("Job runner: Job %v unstarted: %v before job's start time %v", job.ID, now, job.StartAt),
+			reason: "job has not started",
 		}
 	}
 	if job.Ended(now) {
 		return models.JobRun{}, JobRunnerError{
-			msg: fmt.Sprintf("Job runner: Job %v ended: %v past job's end time %v", job.ID, now, job.EndAt),
+			msg:    fmt.Sprintf("Job runner: Job %v ended: %v past job's end time %v", job.ID, now, job.EndAt),
+			reason: "job has ended",
 		}
 	}
 	return job.NewRun(), nil
@@ -40,13 +44,14 @@ func BuildRun(job models.JobSpec, store *store.Store) (models.JobRun, error) {
 // ExecuteRun starts the job and executes task runs within that job in the
 // order defined in the run for as long as they do not return errors. Results
 // are saved in the store (db).
-func ExecuteRun(run models.JobRun, store *store.Store, input models.RunResult) (models.JobRun, error) {
+func ExecuteRun(run models.JobRun, store *strpkg.Store, input models.RunResult) (models.JobRun, error) {
 	run.Status = models.StatusInProgress
 	if err := store.Save(&run); err != nil {
 		return run, wrapError(run, err)
 	}
 
 	logger.Infow("Starting job", run.ForLogger()...)
+	store.Publish(strpkg.EventRunStarted, run)
 	unfinished := run.UnfinishedTaskRuns()
 	offset := len(run.TaskRuns) - len(unfinished)
 	prevRun := unfinished[0]
@@ -62,7 +67,7 @@ func ExecuteRun(run models.JobRun, store *store.Store, input models.RunResult) (
 		if err != nil {
 			return run, wrapError(run, err)
 		}
-		prevRun = startTask(taskRun, prevRun.Result, store)
+		prevRun = startTask(run.JobID, taskRun, prevRun.Result, store)
 		logger.Debugw("Produced task run", "tr", prevRun)
 		run.TaskRuns[i+offset] = prevRun
 		if err := store.Save(&run); err != nil {
@@ -80,34 +85,154 @@ func ExecuteRun(run models.JobRun, store *store.Store, input models.RunResult) (
 	}
 
 	run.Result = prevRun.Result
+	run.WakeOn, run.WakeAt = "", null.Time{}
 	if run.Result.HasError() {
 		run.Status = models.StatusErrored
 	} else if run.Result.Pending {
 		run.Status = models.StatusPending
+		run.WakeOn, run.WakeAt = wakeConditionFor(prevRun.Task, store)
 	} else {
 		run.Status = models.StatusCompleted
 		run.CompletedAt = null.Time{Time: time.Now(), Valid: true}
 	}
 
 	logger.Infow("Finished current job run execution", run.ForLogger()...)
-	return run, wrapError(run, store.Save(&run))
+	if err := store.Save(&run); err != nil {
+		return run, wrapError(run, err)
+	}
+	publishRunStatus(run, store)
+	if run.WakeOn == models.WakeAtTime {
+		wakeAt(run, store)
+	}
+	return run, nil
+}
+
+// EnqueueRun adds the execution of the run to the store's RunQueue at the
+// run's priority, logging the error if it fails. A run the queue rejects
+// because the node is stopping is left in the store for ResumePendingRuns.
+func EnqueueRun(run models.JobRun, store *strpkg.Store, input models.RunResult) {
+	err := store.RunQueue.Enqueue(run.ID, run.Priority, func() {
+		if _, err := ExecuteRun(run, store, input); err != nil {
+			logger.Errorw(err.Error(), run.ForLogger()...)
+		}
+	})
+	if err != nil {
+		logger.Warnw(err.Error(), run.ForLogger()...)
+	}
+}
+
+// WakeRun adds the resumption of a pending run to the store's RunQueue, as
+// EnqueueRun does without input, unless the run is already waiting there.
+func WakeRun(run models.JobRun, store *strpkg.Store) {
+	err := store.RunQueue.Wake(run.ID, run.Priority, func() {
+		if _, err := ExecuteRun(run, store, models.RunResult{}); err != nil {
+			logger.Errorw(err.Error(), run.ForLogger()...)
+		}
+	})
+	if err != nil {
+		logger.Warnw(err.Error(), run.ForLogger()...)
+	}
+}
+
+// wakeConditionFor returns what should resume a run pending on the given
+// task: bridges report back through the API, sleeps until their time, and
+// everything else is checked again on the next block.
+func wakeConditionFor(task models.TaskSpec, store *strpkg.Store) (string, null.Time) {
+	adapter, _ := adapters.For(task, store)
+	switch a := adapter.(type) {
+	case *adapters.Bridge:
+		return models.WakeOnCallback, null.Time{}
+	case *adapters.Sleep:
+		return models.WakeAtTime, null.TimeFrom(a.Until.Time)
+	default:
+		return models.WakeOnHead, null.Time{}
+	}
+}
+
+// ResumePendingRuns re-arms the wake conditions of runs left pending when
+// the node last stopped. Timers are set again for sleeping runs, while runs
+// waiting on the next block or a bridge callback need nothing more than to
+// be found pending in the store. Runs that were saved but still waiting in
+// the RunQueue, such as those of runat initiators, are queued again.
+func ResumePendingRuns(store *strpkg.Store) error {
+	unstarted, err := store.UnstartedJobRuns()
+	if err != nil {
+		return fmt.Errorf("ResumePendingRuns: %v", err)
+	}
+	for _, run := range unstarted {
+		logger.Infow("Queueing run that never started", run.ForLogger()...)
+		EnqueueRun(run, store, models.RunResult{})
+	}
+
+	pending, err := store.PendingJobRuns()
+	if err != nil {
+		return fmt.Errorf("ResumePendingRuns: %v", err)
+	}
+	for _, run := range pending {
+		switch run.WakeOn {
+		case models.WakeAtTime:
+			logger.Infow(fmt.Sprintf("Resuming sleeping run at %v", run.WakeAt.Time), run.ForLogger()...)
+			wakeAt(run, store)
+		case models.WakeOnCallback:
+			logger.Infow("Awaiting bridge callback for pending run", run.ForLogger()...)
+		}
+	}
+	return nil
+}
+
+// wakeAt resumes the run once its WakeAt time has passed, unless it has
+// been resumed by other means in the meantime or the store is closed first.
+// A run left sleeping by a shutdown is resumed by ResumePendingRuns.
+func wakeAt(run models.JobRun, store *strpkg.Store) {
+	go func() {
+		select {
+		case <-store.Done():
+			return
+		case <-store.Clock.After(run.WakeAt.Time.Sub(store.Clock.Now())):
+		}
+		current, err := store.FindJobRun(run.ID)
+		if err != nil {
+			logger.Error(err.Error())
+			return
+		} else if current.Status != models.StatusPending || current.WakeOn != models.WakeAtTime {
+			return
+		}
+		WakeRun(current, store)
+	}()
+}
+
+func publishRunStatus(run models.JobRun, store *strpkg.Store) {
+	switch run.Status {
+	case models.StatusErrored:
+		store.Publish(strpkg.EventRunErrored, run)
+	case models.StatusPending:
+		store.Publish(strpkg.EventRunPending, run)
+	case models.StatusCompleted:
+		store.Publish(strpkg.EventRunCompleted, run)
+	}
 }
 
 func startTask(
+	jobID string,
 	run models.TaskRun,
 	input models.RunResult,
-	store *store.Store,
+	store *strpkg.Store,
 ) models.TaskRun {
 	run.Status = models.StatusInProgress
 
 	adapter, err := adapters.For(run.Task, store)
 	if err != nil {
+		recordJobError(store, jobID, fmt.Sprintf("Invalid params for %v task: %v", run.Task.Type, err))
 		run.Status = models.StatusErrored
 		run.Result.SetError(err)
 		return run
 	}
 
+	input.IdempotencyKey = run.ID
+	start := store.Clock.Now()
 	run.Result = adapter.Perform(input, store)
+	run.Result.IdempotencyKey = ""
+	recordTask(jobID, run, store.Clock.Now().Sub(start), store)
 	if run.Result.HasError() {
 		run.Status = models.StatusErrored
 	} else if run.Result.Pending {
@@ -119,6 +244,24 @@ func startTask(
 	return run
 }
 
+// recordTask adds the time taken by the task's adapter and its outcome to
+// the store's metrics.
+func recordTask(jobID string, run models.TaskRun, duration time.Duration, store *strpkg.Store) {
+	var err error
+	if run.Result.HasError() {
+		err = errors.New(run.Result.Error())
+	}
+	store.Metrics.Record(strings.ToLower(run.Task.Type), jobID, duration, err)
+}
+
+// recordJobError saves a non-fatal error attributable to the job, so that it
+// is shown with the job until it is dismissed.
+func recordJobError(store *strpkg.Store, jobID, description string) {
+	if _, err := store.RecordJobSpecError(jobID, description); err != nil {
+		logger.Warnw("Unable to record job error", "job", jobID, "description", description, "err", err)
+	}
+}
+
 func wrapError(run models.JobRun, err error) error {
 	if err != nil {
 		return fmt.Errorf("ExecuteRun: Job#%v: %v", run.JobID, err)
@@ -126,12 +269,24 @@ func wrapError(run models.JobRun, err error) error {
 	return nil
 }
 
-// JobRunnerError contains the field for the error message.
+// JobRunnerError contains the field for the error message, and a reason
+// that leaves out the details particular to each occurrence.
 type JobRunnerError struct {
-	msg string
+	msg    string
+	reason string
 }
 
 // Error returns the error message for the run.
 func (err JobRunnerError) Error() string {
 	return err.msg
 }
+
+// describeError returns a description of the error that stays the same
+// across occurrences where possible, so that recorded job errors are
+// counted together.
+func describeError(err error) string {
+	if jre, ok := err.(JobRunnerError); ok && jre.reason != "" {
+		return jre.reason
+	}
+	return err.Error()
+}
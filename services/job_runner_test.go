@@ -7,6 +7,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/internal/cltest"
 	"github.com/smartcontractkit/chainlink/services"
+	strpkg "github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/stretchr/testify/assert"
 	null "gopkg.in/guregu/null.v3"
@@ -40,7 +41,7 @@ func TestJobRunner_ExecuteRun(t *testing.T) {
 			var run models.JobRun
 			mockServer, cleanup := cltest.NewHTTPMockServer(t, 200, "POST", test.runResult,
 				func(body string) {
-					want := fmt.Sprintf(`{"id":"%v","data":%v}`, run.ID, test.input)
+					want := fmt.Sprintf(`{"id":"%v","idempotencyKey":"%v","data":%v}`, run.ID, run.TaskRuns[0].ID, test.input)
 					assert.JSONEq(t, want, body)
 				})
 			defer cleanup()
@@ -88,6 +89,113 @@ func TestJobRunner_ExecuteRun_TransitionToPending(t *testing.T) {
 	assert.Equal(t, models.StatusPending, run.Status)
 }
 
+func TestJobRunner_ExecuteRun_WakeConditions(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	store.Clock = cltest.NeverClock{}
+
+	mockServer, cleanup := cltest.NewHTTPMockServer(t, 200, "POST", `{"pending":true}`)
+	defer cleanup()
+	bt := cltest.NewBridgeType("pendingBridge", mockServer.URL)
+	assert.Nil(t, store.Save(&bt))
+	until := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	tests := []struct {
+		name       string
+		task       models.TaskSpec
+		wantWakeOn string
+		wantWakeAt null.Time
+	}{
+		{"ethtx style", cltest.NewTask("NoOpPend"), models.WakeOnHead, null.Time{}},
+		{"bridge", cltest.NewTask(bt.Name), models.WakeOnCallback, null.Time{}},
+		{"sleep", cltest.NewTask("sleep", fmt.Sprintf(`{"until":"%v"}`, until.Format(time.RFC3339))),
+			models.WakeAtTime, null.TimeFrom(until)},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			job := models.NewJob()
+			job.Tasks = []models.TaskSpec{test.task, cltest.NewTask("NoOp")}
+			run, err := services.ExecuteRun(job.NewRun(), store, models.RunResult{})
+			assert.Nil(t, err)
+
+			assert.Nil(t, store.One("ID", run.ID, &run))
+			assert.Equal(t, models.StatusPending, run.Status)
+			assert.Equal(t, test.wantWakeOn, run.WakeOn)
+			assert.Equal(t, test.wantWakeAt.Valid, run.WakeAt.Valid)
+			assert.True(t, test.wantWakeAt.Time.Equal(run.WakeAt.Time))
+		})
+	}
+}
+
+func TestJobRunner_ResumePendingRuns(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	until := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	job := models.NewJob()
+	job.Tasks = []models.TaskSpec{
+		cltest.NewTask("sleep", fmt.Sprintf(`{"until":"%v"}`, until.Format(time.RFC3339))),
+		cltest.NewTask("NoOp"),
+	}
+	assert.Nil(t, store.SaveJob(&job))
+
+	sleeping := job.NewRun()
+	sleeping.TaskRuns[0].Status = models.StatusPending
+	sleeping.TaskRuns[0].Result = sleeping.TaskRuns[0].Result.MarkPending()
+	sleeping.Status = models.StatusPending
+	sleeping.WakeOn = models.WakeAtTime
+	sleeping.WakeAt = null.TimeFrom(until)
+	assert.Nil(t, store.Save(&sleeping))
+
+	callback := job.NewRun()
+	callback.Status = models.StatusPending
+	callback.WakeOn = models.WakeOnCallback
+	assert.Nil(t, store.Save(&callback))
+
+	unstarted := job.NewRun()
+	assert.Nil(t, store.Save(&unstarted))
+
+	assert.Nil(t, services.ResumePendingRuns(store))
+
+	cltest.WaitForJobRunToComplete(t, store, sleeping)
+	cltest.WaitForJobRunToComplete(t, store, unstarted)
+	assert.Nil(t, store.One("ID", callback.ID, &callback))
+	assert.Equal(t, models.StatusPending, callback.Status)
+}
+
+func TestJobRunner_ExecuteRun_PublishesEvents(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		taskType   string
+		wantEvents []string
+	}{
+		{"completed", "NoOp", []string{strpkg.EventRunStarted, strpkg.EventRunCompleted}},
+		{"pending", "NoOpPend", []string{strpkg.EventRunStarted, strpkg.EventRunPending}},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			store, cleanup := cltest.NewStore()
+			defer cleanup()
+			pub := cltest.UseMockPublisher(store)
+
+			job := models.NewJob()
+			job.Tasks = []models.TaskSpec{{Type: test.taskType}}
+
+			_, err := services.ExecuteRun(job.NewRun(), store, models.RunResult{})
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantEvents, pub.Types())
+		})
+	}
+}
+
 func TestJobRunner_BeginRun(t *testing.T) {
 	pastTime := cltest.ParseNullableTime("2000-01-01T00:00:00.000Z")
 	futureTime := cltest.ParseNullableTime("3000-01-01T00:00:00.000Z")
@@ -171,3 +279,120 @@ func TestJobRunner_BuildRun(t *testing.T) {
 		})
 	}
 }
+
+func TestJobRunner_ExecuteRun_RecordsAdapterMetrics(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	mockServer, cleanup := cltest.NewHTTPMockServer(t, 500, "GET", `{}`)
+	defer cleanup()
+
+	job := models.NewJob()
+	job.Tasks = []models.TaskSpec{
+		{Type: "NoOp"},
+		cltest.NewTask("httpget", fmt.Sprintf(`{"url":"%v"}`, mockServer.URL)),
+	}
+	assert.Nil(t, store.Save(&job))
+
+	run, err := services.ExecuteRun(job.NewRun(), store, models.RunResult{})
+	assert.Nil(t, err)
+	assert.Equal(t, models.StatusErrored, run.Status)
+
+	stats := store.Metrics.ByJob()
+	assert.Equal(t, 2, len(stats))
+	assert.Equal(t, "httpget", stats[0].Adapter)
+	assert.Equal(t, job.ID, stats[0].JobID)
+	assert.Equal(t, uint64(1), stats[0].Count)
+	assert.Equal(t, uint64(1), stats[0].Errors)
+	assert.NotEmpty(t, stats[0].LastError)
+	assert.Equal(t, "noop", stats[1].Adapter)
+	assert.Equal(t, uint64(1), stats[1].Count)
+	assert.Equal(t, uint64(0), stats[1].Errors)
+}
+
+func TestJobRunner_ExecuteRun_DoesNotSaveIdempotencyKey(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	job := models.NewJob()
+	job.Tasks = []models.TaskSpec{{Type: "NoOp"}, {Type: "NoOp"}}
+	assert.Nil(t, store.Save(&job))
+
+	run, err := services.ExecuteRun(job.NewRun(), store, models.RunResult{})
+	assert.Nil(t, err)
+	assert.Equal(t, models.StatusCompleted, run.Status)
+
+	assert.Nil(t, store.One("ID", run.ID, &run))
+	assert.Empty(t, run.Result.IdempotencyKey)
+	for _, tr := range run.TaskRuns {
+		assert.Empty(t, tr.Result.IdempotencyKey)
+	}
+}
+
+func TestJobRunner_EnqueueRun_CarriesJobPriority(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	job := models.NewJob()
+	job.Priority = models.PriorityHigh
+	job.Tasks = []models.TaskSpec{{Type: "NoOp"}}
+	assert.Nil(t, store.Save(&job))
+
+	run := job.NewRun()
+	assert.Equal(t, models.PriorityHigh, run.Priority)
+	services.EnqueueRun(run, store, models.RunResult{})
+
+	assert.Nil(t, store.One("ID", run.ID, &run))
+	assert.Equal(t, models.StatusCompleted, run.Status)
+	assert.Equal(t, models.PriorityHigh, run.Priority)
+}
+
+func TestJobRunner_EnqueueRun_WithWorkers(t *testing.T) {
+	t.Parallel()
+	config, cfgCleanup := cltest.NewConfig()
+	defer cfgCleanup()
+	config.RunQueueWorkers = 2
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+	store.RunQueue.Start()
+
+	job := models.NewJob()
+	job.Tasks = []models.TaskSpec{{Type: "NoOp"}}
+	assert.Nil(t, store.Save(&job))
+
+	runs := []models.JobRun{}
+	for i := 0; i < 3; i++ {
+		run := job.NewRun()
+		assert.Nil(t, store.Save(&run))
+		services.EnqueueRun(run, store, models.RunResult{})
+		runs = append(runs, run)
+	}
+	for _, run := range runs {
+		cltest.WaitForJobRunToComplete(t, store, run)
+	}
+}
+
+func TestJobRunner_ExecuteRun_RecordsInvalidParams(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	job := models.NewJob()
+	job.Tasks = []models.TaskSpec{cltest.NewTask("httpget", `{"url":5}`)}
+	assert.Nil(t, store.Save(&job))
+
+	for i := 0; i < 2; i++ {
+		run, err := services.ExecuteRun(job.NewRun(), store, models.RunResult{})
+		assert.Nil(t, err)
+		assert.Equal(t, models.StatusErrored, run.Status)
+	}
+
+	errs, err := store.JobSpecErrorsFor(job.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(errs))
+	assert.Contains(t, errs[0].Description, "Invalid params for httpget task")
+	assert.Equal(t, uint(2), errs[0].Occurrences)
+}
@@ -119,10 +119,14 @@ func (r *Recurring) AddJob(job models.JobSpec) {
 		cronStr := string(initr.Schedule)
 		if !job.Ended(r.Clock.Now()) {
 			r.Cron.AddFunc(cronStr, func() {
-				_, err := BeginRun(job, r.store, models.RunResult{})
-				if err != nil && !expectedRecurringError(err) {
-					logger.Error(err.Error())
+				run, err := BuildRun(job, r.store)
+				if err != nil {
+					if !expectedRecurringError(err) {
+						logger.Error(err.Error())
+					}
+					return
 				}
+				EnqueueRun(run, r.store, models.RunResult{})
 			})
 		}
 	}
@@ -144,7 +148,7 @@ func (ot *OneTime) Start() error {
 // AddJob runs the job at the time specified for the "runat" initiator.
 func (ot *OneTime) AddJob(job models.JobSpec) {
 	for _, initr := range job.InitiatorsFor(models.InitiatorRunAt) {
-		go ot.RunJobAt(initr.Time, job)
+		go ot.RunJobAt(initr, job)
 	}
 }
 
@@ -154,18 +158,45 @@ func (ot *OneTime) Stop() {
 }
 
 // RunJobAt wait until the Stop() function has been called on the run
-// or the specified time for the run is after the present time.
-func (ot *OneTime) RunJobAt(t models.Time, job models.JobSpec) {
+// or the specified time for the run is after the present time. Initiators
+// that have already run are skipped, and those whose time passed while the
+// node was down are run immediately.
+func (ot *OneTime) RunJobAt(initr models.Initiator, job models.JobSpec) {
+	if initr.Ran {
+		logger.Debugw(fmt.Sprintf("Skipping runat for job %v, already ran", job.ID), "time", initr.Time.ISO8601())
+		return
+	}
+	wait := initr.Time.DurationFromNow()
+	if wait < 0 {
+		logger.Infow(fmt.Sprintf("Job %v missed its runat time, running now", job.ID), "time", initr.Time.ISO8601())
+	}
+
 	select {
 	case <-ot.done:
-	case <-ot.Clock.After(t.DurationFromNow()):
-		_, err := BeginRun(job, ot.Store, models.RunResult{})
-		if err != nil {
+	case <-ot.Clock.After(wait):
+		if err := ot.runOnce(initr, job); err != nil {
 			logger.Error(err.Error())
 		}
 	}
 }
 
+// runOnce marks the initiator as ran as the run is saved, before queueing
+// it, so that restarting the node never fires the same initiator twice. A
+// run saved but not yet executed when the node stops is queued again by
+// ResumePendingRuns. Errors executing the run are logged by the RunQueue
+// rather than returned.
+func (ot *OneTime) runOnce(initr models.Initiator, job models.JobSpec) error {
+	run, err := BuildRun(job, ot.Store)
+	if err != nil {
+		return err
+	}
+	if err := ot.Store.MarkRan(initr, &run); err != nil {
+		return err
+	}
+	EnqueueRun(run, ot.Store, models.RunResult{})
+	return nil
+}
+
 func expectedRecurringError(err error) bool {
 	switch err.(type) {
 	case JobRunnerError:
@@ -109,7 +109,8 @@ func TestOneTime_RunJobAt(t *testing.T) {
 
 	var finished bool
 	go func() {
-		ot.RunJobAt(models.Time{time.Now().Add(time.Hour)}, j)
+		initr := models.Initiator{Type: models.InitiatorRunAt, Time: models.Time{time.Now().Add(time.Hour)}}
+		ot.RunJobAt(initr, j)
 		finished = true
 	}()
 
@@ -123,6 +124,41 @@ func TestOneTime_RunJobAt(t *testing.T) {
 	assert.Equal(t, 0, len(jobRuns))
 }
 
+func TestOneTime_RunJobAt_MarksRan(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	ot := services.OneTime{
+		Clock: cltest.InstantClock{},
+		Store: store,
+	}
+	ot.Start()
+	defer ot.Stop()
+	j := cltest.NewJob()
+	j.Initiators = []models.Initiator{{
+		Type: models.InitiatorRunAt,
+		Time: models.Time{time.Now().Add(-time.Hour)},
+	}}
+	assert.Nil(t, store.SaveJob(&j))
+	stale := j.Initiators[0]
+
+	ot.RunJobAt(stale, j)
+	cltest.WaitForRuns(t, j, store, 1)
+
+	var initr models.Initiator
+	assert.Nil(t, store.One("ID", stale.ID, &initr))
+	assert.True(t, initr.Ran)
+	j, err := store.FindJob(j.ID)
+	assert.Nil(t, err)
+	assert.True(t, j.Initiators[0].Ran)
+
+	ot.RunJobAt(stale, j)
+	ot.RunJobAt(j.Initiators[0], j)
+	cltest.WaitForRuns(t, j, store, 1)
+}
+
 func TestScheduler_Start_AddingUnstartedJob(t *testing.T) {
 	logs := cltest.ObserveLogs()
 
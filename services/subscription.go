@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -125,6 +126,7 @@ func (sub RPCLogSubscription) Unsubscribe() {
 func (sub RPCLogSubscription) listenToSubscriptionErrors() {
 	for err := range sub.errors {
 		logger.Errorw(fmt.Sprintf("Error in log subscription for job %v", sub.Job.ID), "err", err, "initr", sub.Initiator)
+		recordJobError(sub.store, sub.Job.ID, fmt.Sprintf("Error in log subscription: %v", err))
 	}
 }
 
@@ -195,9 +197,19 @@ func ReceiveEthLog(le RPCLogEvent) {
 
 func runJob(le RPCLogEvent, data models.JSON) {
 	input := models.RunResult{Data: data}
-	if _, err := BeginRun(le.Job, le.store, input); err != nil {
+	run, err := BuildRun(le.Job, le.store)
+	if err != nil {
 		logger.Errorw(err.Error(), le.ForLogger()...)
+		recordJobError(le.store, le.Job.ID, fmt.Sprintf("Rejected run for a log: %v", describeError(err)))
+		return
 	}
+
+	run.TxHash = le.Log.TxHash
+	run.Requester = le.Requester()
+	if le.Initiator.Type == models.InitiatorRunLog {
+		run.RequestID = le.Log.Topics[EventTopicRequestID]
+	}
+	EnqueueRun(run, le.store, input)
 }
 
 // Encapsulates all information as a result of a received log from an
@@ -220,6 +232,36 @@ func (le RPCLogEvent) ForLogger(kvs ...interface{}) []interface{} {
 	return append(kvs, output...)
 }
 
+// requesterTimeout bounds how long log intake waits on the node for the
+// transaction that emitted a log.
+const requesterTimeout = 5 * time.Second
+
+// Requester returns the sender of the transaction that emitted the log, or
+// the zero address if the transaction can't be retrieved within
+// requesterTimeout, so that a slow node does not stall log intake.
+func (le RPCLogEvent) Requester() common.Address {
+	type result struct {
+		tx  store.Transaction
+		err error
+	}
+	results := make(chan result, 1)
+	go func() {
+		tx, err := le.store.TxManager.GetTransaction(le.Log.TxHash)
+		results <- result{tx, err}
+	}()
+	select {
+	case r := <-results:
+		if r.err != nil {
+			logger.Warnw("Unable to retrieve requester of log", le.ForLogger("err", r.err.Error())...)
+			return common.Address{}
+		}
+		return r.tx.From
+	case <-time.After(requesterTimeout):
+		logger.Warnw(fmt.Sprintf("Timed out after %v retrieving requester of log", requesterTimeout), le.ForLogger()...)
+		return common.Address{}
+	}
+}
+
 // Return whether or not the contained log is a RunLog, a specific Chainlink event trigger
 // from smart contracts.
 func (le RPCLogEvent) ValidateRunLog() bool {
@@ -0,0 +1,116 @@
+package store
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// AdapterStats summarizes the executions of one adapter type, either within
+// a single job or, when JobID is empty, across all jobs.
+type AdapterStats struct {
+	Adapter       string          `json:"adapter"`
+	JobID         string          `json:"jobId,omitempty"`
+	Count         uint64          `json:"count"`
+	Errors        uint64          `json:"errors"`
+	TotalDuration models.Duration `json:"totalDuration"`
+	MaxDuration   models.Duration `json:"maxDuration"`
+	LastError     string          `json:"lastError,omitempty"`
+}
+
+// AverageDuration returns the mean time taken per execution.
+func (as AdapterStats) AverageDuration() time.Duration {
+	if as.Count == 0 {
+		return 0
+	}
+	return as.TotalDuration.Duration / time.Duration(as.Count)
+}
+
+func (as *AdapterStats) add(other AdapterStats) {
+	as.Count += other.Count
+	as.Errors += other.Errors
+	as.TotalDuration.Duration += other.TotalDuration.Duration
+	if other.MaxDuration.Duration > as.MaxDuration.Duration {
+		as.MaxDuration = other.MaxDuration
+	}
+	if other.LastError != "" {
+		as.LastError = other.LastError
+	}
+}
+
+// AdapterMetrics records how long adapters take to perform and how often
+// they fail, per adapter type and per job. It is kept in memory, so it
+// covers the executions since the node last started.
+type AdapterMetrics struct {
+	entries map[adapterMetricKey]*AdapterStats
+	mutex   sync.RWMutex
+}
+
+type adapterMetricKey struct {
+	adapter string
+	jobID   string
+}
+
+// NewAdapterMetrics returns an empty AdapterMetrics.
+func NewAdapterMetrics() *AdapterMetrics {
+	return &AdapterMetrics{entries: map[adapterMetricKey]*AdapterStats{}}
+}
+
+// Record adds one execution of the adapter type for the job, which took
+// the given duration and failed if err is not nil.
+func (am *AdapterMetrics) Record(adapter, jobID string, duration time.Duration, err error) {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+	key := adapterMetricKey{adapter: adapter, jobID: jobID}
+	entry, ok := am.entries[key]
+	if !ok {
+		entry = &AdapterStats{Adapter: adapter, JobID: jobID}
+		am.entries[key] = entry
+	}
+	sample := AdapterStats{
+		Count:         1,
+		TotalDuration: models.Duration{Duration: duration},
+		MaxDuration:   models.Duration{Duration: duration},
+	}
+	if err != nil {
+		sample.Errors = 1
+		sample.LastError = err.Error()
+	}
+	entry.add(sample)
+}
+
+// ByJob returns the stats of every adapter type in every job, sorted by
+// adapter type and then job.
+func (am *AdapterMetrics) ByJob() []AdapterStats {
+	am.mutex.RLock()
+	defer am.mutex.RUnlock()
+	stats := []AdapterStats{}
+	for _, entry := range am.entries {
+		stats = append(stats, *entry)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Adapter != stats[j].Adapter {
+			return stats[i].Adapter < stats[j].Adapter
+		}
+		return stats[i].JobID < stats[j].JobID
+	})
+	return stats
+}
+
+// ByAdapter returns the stats of every adapter type across all jobs,
+// sorted by adapter type.
+func (am *AdapterMetrics) ByAdapter() []AdapterStats {
+	totals := []AdapterStats{}
+	for _, entry := range am.ByJob() {
+		last := len(totals) - 1
+		if last < 0 || totals[last].Adapter != entry.Adapter {
+			totals = append(totals, AdapterStats{Adapter: entry.Adapter})
+			last++
+		}
+		entry.JobID = ""
+		totals[last].add(entry)
+	}
+	return totals
+}
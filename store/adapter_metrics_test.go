@@ -0,0 +1,41 @@
+package store_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdapterMetrics_Record(t *testing.T) {
+	t.Parallel()
+	am := strpkg.NewAdapterMetrics()
+	am.Record("httpget", "job1", 2*time.Second, nil)
+	am.Record("httpget", "job1", 4*time.Second, errors.New("timeout"))
+	am.Record("httpget", "job2", time.Second, nil)
+	am.Record("noop", "job1", 0, nil)
+
+	byJob := am.ByJob()
+	assert.Equal(t, 3, len(byJob))
+	assert.Equal(t, "job1", byJob[0].JobID)
+	assert.Equal(t, uint64(2), byJob[0].Count)
+	assert.Equal(t, uint64(1), byJob[0].Errors)
+	assert.Equal(t, "timeout", byJob[0].LastError)
+	assert.Equal(t, 4*time.Second, byJob[0].MaxDuration.Duration)
+	assert.Equal(t, 3*time.Second, byJob[0].AverageDuration())
+	assert.Equal(t, "job2", byJob[1].JobID)
+	assert.Equal(t, "noop", byJob[2].Adapter)
+
+	byAdapter := am.ByAdapter()
+	assert.Equal(t, 2, len(byAdapter))
+	assert.Equal(t, "httpget", byAdapter[0].Adapter)
+	assert.Equal(t, "", byAdapter[0].JobID)
+	assert.Equal(t, uint64(3), byAdapter[0].Count)
+	assert.Equal(t, uint64(1), byAdapter[0].Errors)
+	assert.Equal(t, 7*time.Second, byAdapter[0].TotalDuration.Duration)
+	assert.Equal(t, 4*time.Second, byAdapter[0].MaxDuration.Duration)
+	assert.Equal(t, "noop", byAdapter[1].Adapter)
+	assert.Equal(t, uint64(1), byAdapter[1].Count)
+}
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CircuitOpenError is returned for requests to a host whose circuit is
+// open, without the request being made.
+type CircuitOpenError struct {
+	Host  string
+	Until time.Time
+}
+
+func (e CircuitOpenError) Error() string {
+	return fmt.Sprintf("Circuit open for %v after repeated failures, retrying after %v", e.Host, e.Until)
+}
+
+// CircuitBreaker stops adapters from making requests to a host after a
+// number of consecutive failures, for a cooldown period. Once it has
+// passed, requests are let through again and the first failure reopens the
+// circuit, while the first success closes it. A zero number of failures
+// disables the breaker.
+type CircuitBreaker struct {
+	failures int
+	cooldown time.Duration
+	clock    AfterNower
+	circuits map[string]*circuit
+	mutex    sync.Mutex
+}
+
+type circuit struct {
+	failures  int
+	openUntil time.Time
+}
+
+// NewCircuitBreaker returns a CircuitBreaker that opens after the given
+// number of consecutive failures, for the cooldown.
+func NewCircuitBreaker(failures int, cooldown time.Duration, clock AfterNower) *CircuitBreaker {
+	return &CircuitBreaker{
+		failures: failures,
+		cooldown: cooldown,
+		clock:    clock,
+		circuits: map[string]*circuit{},
+	}
+}
+
+// Allow returns a CircuitOpenError if requests to the host are not
+// currently allowed.
+func (cb *CircuitBreaker) Allow(host string) error {
+	if cb.failures <= 0 {
+		return nil
+	}
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	c, ok := cb.circuits[strings.ToLower(host)]
+	if ok && cb.clock.Now().Before(c.openUntil) {
+		return CircuitOpenError{Host: host, Until: c.openUntil}
+	}
+	return nil
+}
+
+// Success records a successful request to the host, closing its circuit.
+func (cb *CircuitBreaker) Success(host string) {
+	if cb.failures <= 0 {
+		return
+	}
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	delete(cb.circuits, strings.ToLower(host))
+}
+
+// Failure records a failed request to the host, opening its circuit if
+// there have been enough consecutive failures.
+func (cb *CircuitBreaker) Failure(host string) {
+	if cb.failures <= 0 {
+		return
+	}
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	key := strings.ToLower(host)
+	c, ok := cb.circuits[key]
+	if !ok {
+		c = &circuit{}
+		cb.circuits[key] = c
+	}
+	c.failures++
+	if c.failures >= cb.failures {
+		c.openUntil = cb.clock.Now().Add(cb.cooldown)
+	}
+}
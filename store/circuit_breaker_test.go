@@ -0,0 +1,45 @@
+package store_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Parallel()
+	start := time.Now()
+	clock := &cltest.SettableClock{}
+	clock.SetTime(start)
+	cb := strpkg.NewCircuitBreaker(2, time.Minute, clock)
+
+	cb.Failure("api.example.com")
+	assert.Nil(t, cb.Allow("api.example.com"))
+	cb.Success("api.example.com")
+	cb.Failure("api.example.com")
+	assert.Nil(t, cb.Allow("api.example.com"), "successes reset the count")
+
+	cb.Failure("API.example.com")
+	err := cb.Allow("api.example.com")
+	assert.Equal(t, strpkg.CircuitOpenError{Host: "api.example.com", Until: start.Add(time.Minute)}, err)
+	assert.Nil(t, cb.Allow("other.example.com"))
+
+	clock.SetTime(start.Add(time.Minute))
+	assert.Nil(t, cb.Allow("api.example.com"))
+	cb.Failure("api.example.com")
+	assert.NotNil(t, cb.Allow("api.example.com"), "reopens on the first failure after cooldown")
+	cb.Success("api.example.com")
+	assert.Nil(t, cb.Allow("api.example.com"))
+}
+
+func TestCircuitBreaker_Disabled(t *testing.T) {
+	t.Parallel()
+	cb := strpkg.NewCircuitBreaker(0, time.Minute, strpkg.Clock{})
+	for i := 0; i < 10; i++ {
+		cb.Failure("api.example.com")
+	}
+	assert.Nil(t, cb.Allow("api.example.com"))
+}
@@ -7,7 +7,9 @@ import (
 	"os"
 	"path"
 	"reflect"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/smartcontractkit/env"
@@ -17,18 +19,38 @@ import (
 // Config holds parameters used by the application which can be overridden
 // by setting environment variables.
 type Config struct {
-	LogLevel            LogLevel `env:"LOG_LEVEL" envDefault:"info"`
-	RootDir             string   `env:"ROOT" envDefault:"~/.chainlink"`
-	Port                string   `env:"PORT" envDefault:"6688"`
-	BasicAuthUsername   string   `env:"USERNAME" envDefault:"chainlink"`
-	BasicAuthPassword   string   `env:"PASSWORD" envDefault:"twochains"`
-	EthereumURL         string   `env:"ETH_URL" envDefault:"ws://localhost:8546"`
-	ChainID             uint64   `env:"ETH_CHAIN_ID" envDefault:"0"`
-	ClientNodeURL       string   `env:"CLIENT_NODE_URL" envDefault:"http://localhost:6688"`
-	EthMinConfirmations uint64   `env:"ETH_MIN_CONFIRMATIONS" envDefault:"12"`
-	EthGasBumpThreshold uint64   `env:"ETH_GAS_BUMP_THRESHOLD" envDefault:"12"`
-	EthGasBumpWei       big.Int  `env:"ETH_GAS_BUMP_WEI" envDefault:"5000000000"`
-	EthGasPriceDefault  big.Int  `env:"ETH_GAS_PRICE_DEFAULT" envDefault:"20000000000"`
+	LogLevel                 LogLevel      `env:"LOG_LEVEL" envDefault:"info"`
+	LogEncoding              string        `env:"LOG_ENCODING" envDefault:"json"`
+	LogSinks                 []string      `env:"LOG_SINKS" envDefault:"stderr,file" envSeparator:","`
+	RootDir                  string        `env:"ROOT" envDefault:"~/.chainlink"`
+	Port                     string        `env:"PORT" envDefault:"6688"`
+	BasicAuthUsername        string        `env:"USERNAME" envDefault:"chainlink"`
+	BasicAuthPassword        string        `env:"PASSWORD" envDefault:"twochains"`
+	EthereumURL              string        `env:"ETH_URL" envDefault:"ws://localhost:8546"`
+	ChainID                  uint64        `env:"ETH_CHAIN_ID" envDefault:"0"`
+	ClientNodeURL            string        `env:"CLIENT_NODE_URL" envDefault:"http://localhost:6688"`
+	EthMinConfirmations      uint64        `env:"ETH_MIN_CONFIRMATIONS" envDefault:"12"`
+	EthGasBumpThreshold      uint64        `env:"ETH_GAS_BUMP_THRESHOLD" envDefault:"12"`
+	EthGasBumpWei            big.Int       `env:"ETH_GAS_BUMP_WEI" envDefault:"5000000000"`
+	EthGasPriceDefault       big.Int       `env:"ETH_GAS_PRICE_DEFAULT" envDefault:"20000000000"`
+	EthGasPriceFloor         big.Int       `env:"ETH_GAS_PRICE_FLOOR" envDefault:"1000000000"`
+	EthGasPriceCeiling       big.Int       `env:"ETH_GAS_PRICE_CEILING" envDefault:"500000000000"`
+	EthGasPriceRefreshBlocks uint64        `env:"ETH_GAS_PRICE_REFRESH_BLOCKS" envDefault:"10"`
+	EthHeadTimeout           time.Duration `env:"ETH_HEAD_TIMEOUT" envDefault:"2m"`
+	EthHeadCheckInterval     time.Duration `env:"ETH_HEAD_CHECK_INTERVAL" envDefault:"1m"`
+	EthHeadMaxLag            uint64        `env:"ETH_HEAD_MAX_LAG" envDefault:"5"`
+	EthHeadHistory           int           `env:"ETH_HEAD_HISTORY" envDefault:"100"`
+	EthReconnectAlertAfter   int           `env:"ETH_RECONNECT_ALERT_AFTER" envDefault:"5"`
+	EthReconnectMaxAttempts  int           `env:"ETH_RECONNECT_MAX_ATTEMPTS" envDefault:"0"`
+	PublisherURL             string        `env:"PUBLISHER_URL" envDefault:""`
+	PublisherTopic           string        `env:"PUBLISHER_TOPIC" envDefault:"chainlink"`
+	ENSRegistryAddress       string        `env:"ENS_REGISTRY_ADDRESS" envDefault:"0x314159265dD8dbb310642f98f50C066173C1259b"`
+	ENSRefreshInterval       time.Duration `env:"ENS_REFRESH_INTERVAL" envDefault:"10m"`
+	RunQueueWorkers          int           `env:"RUN_QUEUE_WORKERS" envDefault:"4"`
+	RunQueueAging            time.Duration `env:"RUN_QUEUE_AGING" envDefault:"1m"`
+	HTTPRateLimits           RateLimits    `env:"HTTP_RATE_LIMITS" envDefault:""`
+	HTTPCircuitFailures      int           `env:"HTTP_CIRCUIT_FAILURES" envDefault:"5"`
+	HTTPCircuitCooldown      time.Duration `env:"HTTP_CIRCUIT_COOLDOWN" envDefault:"1m"`
 }
 
 // NewConfig returns the config with the environment variables set to their
@@ -54,10 +76,18 @@ func (c Config) KeysDir() string {
 	return path.Join(c.RootDir, "keys")
 }
 
+// ENSRegistry returns the address of the ENS registry used to resolve
+// names in job specs.
+func (c Config) ENSRegistry() common.Address {
+	return common.HexToAddress(c.ENSRegistryAddress)
+}
+
 func parseEnv(cfg interface{}) error {
 	return env.ParseWithFuncs(cfg, env.CustomParsers{
-		reflect.TypeOf(big.Int{}):  bigIntParser,
-		reflect.TypeOf(LogLevel{}): levelParser,
+		reflect.TypeOf(big.Int{}):        bigIntParser,
+		reflect.TypeOf(LogLevel{}):       levelParser,
+		reflect.TypeOf(time.Duration(0)): durationParser,
+		reflect.TypeOf(RateLimits{}):     rateLimitsParser,
 	})
 }
 
@@ -69,6 +99,14 @@ func bigIntParser(str string) (interface{}, error) {
 	return *i, nil
 }
 
+func durationParser(str string) (interface{}, error) {
+	return time.ParseDuration(str)
+}
+
+func rateLimitsParser(str string) (interface{}, error) {
+	return ParseRateLimits(str)
+}
+
 func levelParser(str string) (interface{}, error) {
 	var lvl LogLevel
 	err := lvl.Set(str)
@@ -2,6 +2,8 @@ package store
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	"math/big"
 
@@ -16,6 +18,8 @@ import (
 // EthClient holds the CallerSubscriber interface for the Ethereum blockchain.
 type EthClient struct {
 	CallerSubscriber
+	clientType      models.ClientType
+	clientTypeMutex sync.RWMutex
 }
 
 // CallerSubscriber implements the Call and EthSubscribe functions. Call performs
@@ -54,6 +58,19 @@ func (eth *EthClient) GetEthBalance(address common.Address) (float64, error) {
 	return utils.WeiToEth(numWei), nil
 }
 
+// GetGasPrice returns the node's current estimate of the gas price, in wei.
+func (eth *EthClient) GetGasPrice() (*big.Int, error) {
+	result := ""
+	gasPrice := new(big.Int)
+	if err := eth.Call(&result, "eth_gasPrice"); err != nil {
+		return gasPrice, err
+	}
+	if _, ok := gasPrice.SetString(result, 0); !ok {
+		return gasPrice, fmt.Errorf("Unable to parse gas price %v", result)
+	}
+	return gasPrice, nil
+}
+
 // SendRawTx sends a signed transaction to the transaction pool.
 func (eth *EthClient) SendRawTx(hex string) (common.Hash, error) {
 	result := common.Hash{}
@@ -77,6 +94,77 @@ func (eth *EthClient) GetBlockNumber() (uint64, error) {
 	return utils.HexToUint64(result)
 }
 
+// Function selectors for resolver(bytes32) on the ENS registry and
+// addr(bytes32) on a public resolver.
+const (
+	ensResolverSelector = "0x0178b8bf"
+	ensAddrSelector     = "0x3b3b57de"
+)
+
+// ResolveENSName looks up the resolver for the name in the given ENS registry
+// and returns the address that resolver currently reports for it.
+func (eth *EthClient) ResolveENSName(registry common.Address, name string) (common.Address, error) {
+	node := utils.ENSNamehash(name)
+	resolver, err := eth.callForAddress(registry, ensResolverSelector, node)
+	if err != nil {
+		return common.Address{}, err
+	} else if utils.IsEmptyAddress(resolver) {
+		return resolver, fmt.Errorf("ENS name %v has no resolver", name)
+	}
+
+	addr, err := eth.callForAddress(resolver, ensAddrSelector, node)
+	if err != nil {
+		return common.Address{}, err
+	} else if utils.IsEmptyAddress(addr) {
+		return addr, fmt.Errorf("ENS name %v does not resolve to an address", name)
+	}
+	return addr, nil
+}
+
+func (eth *EthClient) callForAddress(to common.Address, selector string, node common.Hash) (common.Address, error) {
+	result := ""
+	args := map[string]string{
+		"to":   to.Hex(),
+		"data": utils.HexConcat(selector, node.Hex()),
+	}
+	if err := eth.Call(&result, "eth_call", args, "latest"); err != nil {
+		return common.Address{}, err
+	}
+	return common.HexToAddress(result), nil
+}
+
+// GetClientVersion returns the implementation and version of the node.
+func (eth *EthClient) GetClientVersion() (string, error) {
+	result := ""
+	err := eth.Call(&result, "web3_clientVersion")
+	return result, err
+}
+
+// DetectClientType asks the node for its version and remembers which
+// client it is, so that subscriptions are made in a form it understands.
+func (eth *EthClient) DetectClientType() (models.ClientType, error) {
+	version, err := eth.GetClientVersion()
+	ct := models.ClientUnknown
+	if err == nil {
+		ct = models.ParseClientType(version)
+	}
+	eth.clientTypeMutex.Lock()
+	eth.clientType = ct
+	eth.clientTypeMutex.Unlock()
+	return ct, err
+}
+
+// ClientType returns the client detected by the last call to
+// DetectClientType.
+func (eth *EthClient) ClientType() models.ClientType {
+	eth.clientTypeMutex.RLock()
+	defer eth.clientTypeMutex.RUnlock()
+	if eth.clientType == "" {
+		return models.ClientUnknown
+	}
+	return eth.clientType
+}
+
 // SubscribeToLogs registers a subscription for push notifications of logs
 // from a given address.
 func (eth *EthClient) SubscribeToLogs(
@@ -85,7 +173,11 @@ func (eth *EthClient) SubscribeToLogs(
 ) (models.EthSubscription, error) {
 	// https://github.com/ethereum/go-ethereum/blob/762f3a48a00da02fe58063cb6ce8dc2d08821f15/ethclient/ethclient.go#L359
 	ctx := context.Background()
-	sub, err := eth.EthSubscribe(ctx, channel, "logs", utils.ToFilterArg(q))
+	arg := utils.ToFilterArg(q)
+	if eth.ClientType() == models.ClientParity {
+		arg = utils.ToParityFilterArg(q)
+	}
+	sub, err := eth.EthSubscribe(ctx, channel, "logs", arg)
 	return sub, err
 }
 
@@ -98,11 +190,25 @@ func (eth *EthClient) SubscribeToNewHeads(
 	return sub, err
 }
 
-// TxReceipt holds the block number and the transaction hash of a signed
-// transaction that has been written to the blockchain.
+// Transaction holds the hash and sender of a transaction.
+type Transaction struct {
+	Hash common.Hash    `json:"hash"`
+	From common.Address `json:"from"`
+}
+
+// GetTransaction returns the transaction with the given hash.
+func (eth *EthClient) GetTransaction(hash common.Hash) (Transaction, error) {
+	tx := Transaction{}
+	err := eth.Call(&tx, "eth_getTransactionByHash", hash.String())
+	return tx, err
+}
+
+// TxReceipt holds the block number, the transaction hash and the gas used
+// by a signed transaction that has been written to the blockchain.
 type TxReceipt struct {
-	BlockNumber hexutil.Big `json:"blockNumber"`
-	Hash        common.Hash `json:"transactionHash"`
+	BlockNumber hexutil.Big    `json:"blockNumber"`
+	Hash        common.Hash    `json:"transactionHash"`
+	GasUsed     hexutil.Uint64 `json:"gasUsed"`
 }
 
 // Unconfirmed returns true if the transaction is not confirmed.
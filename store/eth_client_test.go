@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -79,3 +80,64 @@ func TestEthGetBalance(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expected, result)
 }
+
+func TestEthClient_ResolveENSName(t *testing.T) {
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	ethMock := app.MockEthClient()
+	ethClientObject := app.Store.TxManager.EthClient
+	registry := cltest.NewAddress()
+	resolver := cltest.NewAddress()
+	want := cltest.NewAddress()
+
+	ethMock.Register("eth_call", common.BytesToHash(resolver.Bytes()).Hex(),
+		func(_ interface{}, data ...interface{}) error {
+			args := data[0].([]interface{})[0].(map[string]string)
+			assert.Equal(t, registry.Hex(), args["to"])
+			return nil
+		})
+	ethMock.Register("eth_call", common.BytesToHash(want.Bytes()).Hex(),
+		func(_ interface{}, data ...interface{}) error {
+			args := data[0].([]interface{})[0].(map[string]string)
+			assert.Equal(t, resolver.Hex(), args["to"])
+			return nil
+		})
+
+	result, err := ethClientObject.ResolveENSName(registry, "oracle.example.eth")
+	assert.Nil(t, err)
+	assert.Equal(t, want, result)
+	ethMock.EnsureAllCalled(t)
+}
+
+func TestEthClient_ResolveENSName_NoResolver(t *testing.T) {
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	ethMock := app.MockEthClient()
+	ethClientObject := app.Store.TxManager.EthClient
+
+	ethMock.Register("eth_call", common.Hash{}.Hex())
+
+	_, err := ethClientObject.ResolveENSName(cltest.NewAddress(), "missing.eth")
+	assert.NotNil(t, err)
+	ethMock.EnsureAllCalled(t)
+}
+
+func TestEthClient_DetectClientType(t *testing.T) {
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	ethMock := app.MockEthClient()
+	ethClientObject := app.Store.TxManager.EthClient
+	assert.Equal(t, models.ClientUnknown, ethClientObject.ClientType())
+
+	ethMock.Register("web3_clientVersion", "Parity//v1.10.0-beta-e1ba1a8-20180302/x86_64-linux-gnu/rustc1.24.0")
+	ct, err := ethClientObject.DetectClientType()
+	assert.Nil(t, err)
+	assert.Equal(t, models.ClientParity, ct)
+	assert.Equal(t, models.ClientParity, ethClientObject.ClientType())
+
+	ethMock.RegisterError("web3_clientVersion", "method not found")
+	_, err = ethClientObject.DetectClientType()
+	assert.NotNil(t, err)
+	assert.Equal(t, models.ClientUnknown, ethClientObject.ClientType())
+	ethMock.EnsureAllCalled(t)
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/utils"
+)
+
+// Scope limits what an APIToken may be used for.
+type Scope string
+
+const (
+	// ScopeReadOnly allows viewing specs, runs and stats.
+	ScopeReadOnly = Scope("read-only")
+	// ScopeRunTrigger additionally allows starting and resuming runs.
+	ScopeRunTrigger = Scope("run-trigger")
+	// ScopeAdmin allows everything, including managing specs and tokens.
+	ScopeAdmin = Scope("admin")
+)
+
+var scopeLevels = map[Scope]int{
+	ScopeReadOnly:   1,
+	ScopeRunTrigger: 2,
+	ScopeAdmin:      3,
+}
+
+// UnmarshalJSON parses the scope, rejecting unknown values.
+func (s *Scope) UnmarshalJSON(input []byte) error {
+	var str string
+	if err := json.Unmarshal(input, &str); err != nil {
+		return err
+	}
+	scope := Scope(str)
+	if _, ok := scopeLevels[scope]; !ok {
+		return fmt.Errorf("Unknown scope %v", str)
+	}
+	*s = scope
+	return nil
+}
+
+// Permits returns true if the scope includes everything allowed by the
+// required scope.
+func (s Scope) Permits(required Scope) bool {
+	level, ok := scopeLevels[s]
+	return ok && level >= scopeLevels[required]
+}
+
+// APIToken is a long lived credential for the node's API. Only the hash of
+// the token is stored, the token itself is shown once when it is created.
+// A token with an Owner acts on behalf of that User, otherwise it acts for
+// the node's operator.
+type APIToken struct {
+	ID        string    `json:"id" storm:"id,unique"`
+	Hash      string    `json:"-" storm:"unique"`
+	Scope     Scope     `json:"scope"`
+	Owner     string    `json:"owner,omitempty" storm:"index"`
+	CreatedAt time.Time `json:"createdAt" storm:"index"`
+}
+
+// NewAPIToken generates a token with the given scope and owner, returning it
+// along with the secret to be handed to the client.
+func NewAPIToken(scope Scope, owner string) (APIToken, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return APIToken{}, "", err
+	}
+	secret := hex.EncodeToString(b)
+	return APIToken{
+		ID:        utils.NewBytes32ID(),
+		Hash:      HashAPIToken(secret),
+		Scope:     scope,
+		Owner:     owner,
+		CreatedAt: time.Now(),
+	}, secret, nil
+}
+
+// HashAPIToken returns the hash under which the given token is stored.
+func HashAPIToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -179,3 +179,28 @@ func (c *Cron) UnmarshalJSON(b []byte) error {
 func (c Cron) String() string {
 	return string(c)
 }
+
+// Duration holds a time.Duration that is given in JSON as a string,
+// i.e. "30s" or "1m".
+type Duration struct {
+	time.Duration
+}
+
+// UnmarshalJSON parses the duration string stored in JSON-encoded data.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("Duration: %v", err)
+	}
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("Duration: %v", err)
+	}
+	d.Duration = duration
+	return nil
+}
+
+// MarshalJSON returns the duration as a string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
@@ -149,3 +149,17 @@ func TestTimeDurationFromNow(t *testing.T) {
 	duration := future.DurationFromNow()
 	assert.True(t, 0 < duration)
 }
+
+func TestDuration_JSON(t *testing.T) {
+	t.Parallel()
+	var d models.Duration
+	assert.Nil(t, json.Unmarshal([]byte(`"1m30s"`), &d))
+	assert.Equal(t, 90*time.Second, d.Duration)
+
+	b, err := json.Marshal(d)
+	assert.Nil(t, err)
+	assert.Equal(t, `"1m30s"`, string(b))
+
+	assert.NotNil(t, json.Unmarshal([]byte(`"soon"`), &d))
+	assert.NotNil(t, json.Unmarshal([]byte(`90`), &d))
+}
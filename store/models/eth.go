@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -48,6 +51,138 @@ type TxAttempt struct {
 	Confirmed bool
 	Hex       string
 	SentAt    uint64
+	GasUsed   uint64
+}
+
+// HeadSample records when a head was received, to measure how quickly the
+// node hears of new blocks and how often they are mined.
+type HeadSample struct {
+	ID         uint64    `json:"id" storm:"id,increment"`
+	Number     uint64    `json:"number"`
+	BlockTime  time.Time `json:"blockTime"`
+	ReceivedAt time.Time `json:"receivedAt" storm:"index"`
+}
+
+// NewHeadSample returns the sample of the header received at the given time.
+func NewHeadSample(header BlockHeader, receivedAt time.Time) HeadSample {
+	return HeadSample{
+		Number:     header.Number.ToInt().Uint64(),
+		BlockTime:  time.Unix(header.Time.ToInt().Int64(), 0),
+		ReceivedAt: receivedAt,
+	}
+}
+
+// HeadTimings summarizes the head samples. Latency is the time between a
+// block's timestamp and the node receiving its head, and Gap is the time
+// between consecutive blocks.
+type HeadTimings struct {
+	Samples        int      `json:"samples"`
+	AverageLatency Duration `json:"averageLatency"`
+	MaxLatency     Duration `json:"maxLatency"`
+	AverageGap     Duration `json:"averageGap"`
+	MaxGap         Duration `json:"maxGap"`
+}
+
+// SummarizeHeadSamples returns the HeadTimings of the samples, which are
+// given oldest first. Blocks skipped between samples are spread evenly over
+// the gap, and samples that don't advance the chain do not count toward it.
+func SummarizeHeadSamples(samples []HeadSample) HeadTimings {
+	timings := HeadTimings{Samples: len(samples)}
+	if len(samples) == 0 {
+		return timings
+	}
+	var latency, gap time.Duration
+	var blocks uint64
+	for i, sample := range samples {
+		l := sample.ReceivedAt.Sub(sample.BlockTime)
+		latency += l
+		if l > timings.MaxLatency.Duration {
+			timings.MaxLatency.Duration = l
+		}
+		if i == 0 || sample.Number <= samples[i-1].Number {
+			continue
+		}
+		n := sample.Number - samples[i-1].Number
+		g := sample.BlockTime.Sub(samples[i-1].BlockTime)
+		gap += g
+		blocks += n
+		if g/time.Duration(n) > timings.MaxGap.Duration {
+			timings.MaxGap.Duration = g / time.Duration(n)
+		}
+	}
+	timings.AverageLatency.Duration = latency / time.Duration(len(samples))
+	if blocks > 0 {
+		timings.AverageGap.Duration = gap / time.Duration(blocks)
+	}
+	return timings
+}
+
+// RunCost is what it cost to fulfill a JobRun with a confirmed transaction.
+type RunCost struct {
+	TxHash    common.Hash `json:"txHash" storm:"id,unique"`
+	JobID     string      `json:"jobId" storm:"index"`
+	JobRunID  string      `json:"jobRunId" storm:"index"`
+	GasUsed   uint64      `json:"gasUsed"`
+	GasPrice  *big.Int    `json:"gasPrice"`
+	EthSpent  *big.Int    `json:"ethSpent"`
+	CreatedAt time.Time   `json:"createdAt" storm:"index"`
+}
+
+// NewRunCost returns the cost of the confirmed transaction sent by the run.
+func NewRunCost(run JobRun, tx Tx) RunCost {
+	gasPrice := new(big.Int)
+	if tx.GasPrice != nil {
+		gasPrice.Set(tx.GasPrice)
+	}
+	return RunCost{
+		TxHash:    tx.Hash,
+		JobID:     run.JobID,
+		JobRunID:  run.ID,
+		GasUsed:   tx.GasUsed,
+		GasPrice:  gasPrice,
+		EthSpent:  new(big.Int).Mul(new(big.Int).SetUint64(tx.GasUsed), gasPrice),
+		CreatedAt: time.Now(),
+	}
+}
+
+// JobCosts sums up the costs of a job's fulfillments, with the ETH spent in
+// wei. The node does not know what a run was paid, so operators compare the
+// average spent against the payment they expect per fulfillment.
+type JobCosts struct {
+	JobID           string   `json:"jobId"`
+	Fulfillments    int      `json:"fulfillments"`
+	GasUsed         uint64   `json:"gasUsed"`
+	EthSpent        *big.Int `json:"ethSpent"`
+	AverageEthSpent *big.Int `json:"averageEthSpent"`
+}
+
+// SummarizeRunCosts returns the JobCosts of every job with the given costs,
+// the most expensive in total first.
+func SummarizeRunCosts(costs []RunCost) []JobCosts {
+	byJob := map[string]*JobCosts{}
+	summaries := []*JobCosts{}
+	for _, cost := range costs {
+		summary, ok := byJob[cost.JobID]
+		if !ok {
+			summary = &JobCosts{JobID: cost.JobID, EthSpent: new(big.Int)}
+			byJob[cost.JobID] = summary
+			summaries = append(summaries, summary)
+		}
+		summary.Fulfillments++
+		summary.GasUsed += cost.GasUsed
+		if cost.EthSpent != nil {
+			summary.EthSpent.Add(summary.EthSpent, cost.EthSpent)
+		}
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].EthSpent.Cmp(summaries[j].EthSpent) > 0
+	})
+	jcs := make([]JobCosts, len(summaries))
+	for i, summary := range summaries {
+		summary.AverageEthSpent = new(big.Int).Div(summary.EthSpent, big.NewInt(int64(summary.Fulfillments)))
+		jcs[i] = *summary
+	}
+	return jcs
 }
 
 // FunctionSelector is the first four bytes of the call data for a
@@ -126,10 +261,61 @@ func (h BlockHeader) Hash() common.Hash {
 	return h.ParityHash
 }
 
+// HashFor returns the block hash using the field populated by the given
+// client. Parity always sends the hash, while older versions of Geth only
+// send the mixHash in newHeads notifications.
+func (h BlockHeader) HashFor(ct ClientType) common.Hash {
+	switch ct {
+	case ClientParity:
+		return h.ParityHash
+	case ClientGeth:
+		if !common.EmptyHash(h.ParityHash) {
+			return h.ParityHash
+		}
+		return h.GethHash
+	default:
+		return h.Hash()
+	}
+}
+
 func (h BlockHeader) IndexableBlockNumber() *IndexableBlockNumber {
 	return NewIndexableBlockNumber(h.Number.ToInt(), h.Hash())
 }
 
+// IndexableBlockNumberFor returns the block number of the header, with the
+// hash as reported by the given client.
+func (h BlockHeader) IndexableBlockNumberFor(ct ClientType) *IndexableBlockNumber {
+	return NewIndexableBlockNumber(h.Number.ToInt(), h.HashFor(ct))
+}
+
+// ClientType identifies the Ethereum node implementation, since Geth and
+// Parity differ in the payloads of their subscriptions.
+type ClientType string
+
+const (
+	// ClientUnknown is used when the client could not be identified.
+	ClientUnknown = ClientType("unknown")
+	// ClientGeth is go-ethereum.
+	ClientGeth = ClientType("geth")
+	// ClientParity is Parity Ethereum.
+	ClientParity = ClientType("parity")
+)
+
+// ParseClientType returns the ClientType for the given web3_clientVersion,
+// i.e. "Geth/v1.8.2-stable/linux-amd64/go1.10" or
+// "Parity//v1.10.0-beta/x86_64-linux-gnu/rustc1.24.0".
+func ParseClientType(version string) ClientType {
+	name := strings.ToLower(strings.SplitN(version, "/", 2)[0])
+	switch {
+	case strings.HasPrefix(name, "geth"):
+		return ClientGeth
+	case strings.HasPrefix(name, "parity"):
+		return ClientParity
+	default:
+		return ClientUnknown
+	}
+}
+
 type IndexableBlockNumber struct {
 	Number hexutil.Big `json:"number" storm:"id,unique"`
 	Digits int         `json:"digits" storm:"index"`
@@ -177,3 +363,16 @@ type EthSubscription interface {
 	Err() <-chan error
 	Unsubscribe()
 }
+
+// ParseAddressOrENSName accepts either a hex encoded address or an ENS name
+// such as "oracle.example.eth". Names are returned lowercased with a zero
+// address, to be resolved against the chain later.
+func ParseAddressOrENSName(s string) (common.Address, string, error) {
+	if common.IsHexAddress(s) {
+		return common.HexToAddress(s), "", nil
+	}
+	if !strings.Contains(s, ".") || strings.HasPrefix(s, "0x") {
+		return common.Address{}, "", fmt.Errorf("%v is neither an address nor an ENS name", s)
+	}
+	return common.Address{}, strings.ToLower(s), nil
+}
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"math/big"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
 	"github.com/smartcontractkit/chainlink/store/models"
@@ -74,6 +76,51 @@ func TestModels_Header_UnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestModels_Header_HashFor(t *testing.T) {
+	t.Parallel()
+	geth := common.HexToHash("0x1")
+	parity := common.HexToHash("0x2")
+	tests := []struct {
+		name   string
+		header models.BlockHeader
+		client models.ClientType
+		want   common.Hash
+	}{
+		{"parity", models.BlockHeader{GethHash: geth, ParityHash: parity}, models.ClientParity, parity},
+		{"geth with hash", models.BlockHeader{GethHash: geth, ParityHash: parity}, models.ClientGeth, parity},
+		{"geth mixHash only", models.BlockHeader{GethHash: geth}, models.ClientGeth, geth},
+		{"unknown", models.BlockHeader{GethHash: geth, ParityHash: parity}, models.ClientUnknown, geth},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, test.header.HashFor(test.client))
+			assert.Equal(t, test.want, test.header.IndexableBlockNumberFor(test.client).Hash)
+		})
+	}
+}
+
+func TestModels_ParseClientType(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		version string
+		want    models.ClientType
+	}{
+		{"Geth/v1.8.2-stable-b8b9f7f4/linux-amd64/go1.10", models.ClientGeth},
+		{"Geth/mynode/v1.8.2-stable/linux-amd64/go1.10", models.ClientGeth},
+		{"Parity//v1.10.0-beta-e1ba1a8-20180302/x86_64-linux-gnu/rustc1.24.0", models.ClientParity},
+		{"Parity-Ethereum//v2.0.1-beta/x86_64-linux-gnu/rustc1.28.0", models.ClientParity},
+		{"EthereumJS TestRPC/v2.1.0/ethereum-js", models.ClientUnknown},
+		{"", models.ClientUnknown},
+	}
+
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			assert.Equal(t, test.want, models.ParseClientType(test.version))
+		})
+	}
+}
+
 func TestModels_IndexableBlockNumber(t *testing.T) {
 	tests := []struct {
 		input      *big.Int
@@ -93,3 +140,87 @@ func TestModels_IndexableBlockNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestModels_SummarizeRunCosts(t *testing.T) {
+	t.Parallel()
+
+	cheap := models.JobSpec{ID: "cheap"}
+	dear := models.JobSpec{ID: "dear"}
+	tx := func(gasUsed, gasPrice int64) models.Tx {
+		return models.Tx{TxAttempt: models.TxAttempt{
+			Hash:     cltest.NewHash(),
+			GasPrice: big.NewInt(gasPrice),
+			GasUsed:  uint64(gasUsed),
+		}}
+	}
+	costs := []models.RunCost{
+		models.NewRunCost(cheap.NewRun(), tx(21000, 1)),
+		models.NewRunCost(dear.NewRun(), tx(50000, 20)),
+		models.NewRunCost(dear.NewRun(), tx(40000, 10)),
+	}
+	assert.Equal(t, big.NewInt(1000000), costs[1].EthSpent)
+
+	summaries := models.SummarizeRunCosts(costs)
+	assert.Equal(t, 2, len(summaries))
+	assert.Equal(t, "dear", summaries[0].JobID)
+	assert.Equal(t, 2, summaries[0].Fulfillments)
+	assert.Equal(t, uint64(90000), summaries[0].GasUsed)
+	assert.Equal(t, big.NewInt(1400000), summaries[0].EthSpent)
+	assert.Equal(t, big.NewInt(700000), summaries[0].AverageEthSpent)
+	assert.Equal(t, "cheap", summaries[1].JobID)
+	assert.Equal(t, big.NewInt(21000), summaries[1].EthSpent)
+
+	assert.Equal(t, 0, len(models.SummarizeRunCosts(nil)))
+}
+
+func TestModels_SummarizeHeadSamples(t *testing.T) {
+	t.Parallel()
+
+	mined := time.Unix(1500000000, 0)
+	d := func(d time.Duration) models.Duration { return models.Duration{Duration: d} }
+	sample := func(number uint64, blockTime, latency time.Duration) models.HeadSample {
+		return models.HeadSample{
+			Number:     number,
+			BlockTime:  mined.Add(blockTime),
+			ReceivedAt: mined.Add(blockTime + latency),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		samples []models.HeadSample
+		want    models.HeadTimings
+	}{
+		{"none", nil, models.HeadTimings{}},
+		{"one", []models.HeadSample{sample(1, 0, 2*time.Second)},
+			models.HeadTimings{Samples: 1, AverageLatency: d(2 * time.Second), MaxLatency: d(2 * time.Second)}},
+		{"consecutive", []models.HeadSample{
+			sample(1, 0, time.Second),
+			sample(2, 10*time.Second, 3*time.Second),
+			sample(3, 30*time.Second, 2*time.Second),
+		}, models.HeadTimings{
+			Samples:        3,
+			AverageLatency: d(2 * time.Second),
+			MaxLatency:     d(3 * time.Second),
+			AverageGap:     d(15 * time.Second),
+			MaxGap:         d(20 * time.Second),
+		}},
+		{"skipped and repeated blocks", []models.HeadSample{
+			sample(1, 0, time.Second),
+			sample(4, 30*time.Second, time.Second),
+			sample(4, 30*time.Second, time.Second),
+		}, models.HeadTimings{
+			Samples:        3,
+			AverageLatency: d(time.Second),
+			MaxLatency:     d(time.Second),
+			AverageGap:     d(10 * time.Second),
+			MaxGap:         d(10 * time.Second),
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, models.SummarizeHeadSamples(test.samples))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
@@ -33,6 +34,8 @@ type JobSpec struct {
 	StartAt    null.Time   `json:"startAt" storm:"index"`
 	EndAt      null.Time   `json:"endAt" storm:"index"`
 	CreatedAt  Time        `json:"createdAt" storm:"index"`
+	Owner      string      `json:"owner,omitempty" storm:"index"`
+	Priority   Priority    `json:"priority,omitempty"`
 }
 
 // NewJob initializes a new job by generating a unique ID and setting
@@ -62,9 +65,49 @@ func (j JobSpec) NewRun() JobRun {
 		JobID:     j.ID,
 		CreatedAt: time.Now(),
 		TaskRuns:  taskRuns,
+		Priority:  j.Priority,
 	}
 }
 
+// Priority orders the runs waiting to execute when the node is saturated.
+// An empty priority is treated as PriorityNormal.
+type Priority string
+
+const (
+	// PriorityHigh is for runs that should go first, i.e. paid requests.
+	PriorityHigh = Priority("high")
+	// PriorityNormal is the default.
+	PriorityNormal = Priority("normal")
+	// PriorityLow is for background work such as cron jobs.
+	PriorityLow = Priority("low")
+)
+
+var priorityLevels = map[Priority]int{
+	PriorityLow:    0,
+	PriorityNormal: 1,
+	"":             1,
+	PriorityHigh:   2,
+}
+
+// UnmarshalJSON parses the priority, rejecting unknown values.
+func (p *Priority) UnmarshalJSON(input []byte) error {
+	var str string
+	if err := json.Unmarshal(input, &str); err != nil {
+		return err
+	}
+	priority := Priority(strings.ToLower(str))
+	if _, ok := priorityLevels[priority]; !ok {
+		return fmt.Errorf("Unknown priority %v", str)
+	}
+	*p = priority
+	return nil
+}
+
+// Level returns the rank of the priority, higher going first.
+func (p Priority) Level() int {
+	return priorityLevels[p]
+}
+
 // InitiatorsFor returns an array of Initiators for the given list of
 // Initiator types.
 func (j JobSpec) InitiatorsFor(types ...string) []Initiator {
@@ -141,19 +184,34 @@ type Initiator struct {
 	Time     Time           `json:"time,omitempty"`
 	Ran      bool           `json:"ran,omitempty"`
 	Address  common.Address `json:"address,omitempty" storm:"index"`
+	ENSName  string         `json:"ensName,omitempty"`
 }
 
 // UnmarshalJSON parses the raw initiator data and updates the
-// initiator as long as the type is valid.
+// initiator as long as the type is valid. The address may be given
+// as an ENS name, which is kept in ENSName until it is resolved.
 func (i *Initiator) UnmarshalJSON(input []byte) error {
 	type Alias Initiator
-	var aux Alias
+	var aux struct {
+		Alias
+		Address string `json:"address"`
+	}
 	if err := json.Unmarshal(input, &aux); err != nil {
 		return err
 	}
 
-	*i = Initiator(aux)
+	*i = Initiator(aux.Alias)
 	i.Type = strings.ToLower(aux.Type)
+	if aux.Address != "" {
+		address, name, err := ParseAddressOrENSName(aux.Address)
+		if err != nil {
+			return err
+		}
+		i.Address = address
+		if name != "" {
+			i.ENSName = name
+		}
+	}
 	return nil
 }
 
@@ -194,14 +252,15 @@ func (t TaskSpec) MarshalJSON() ([]byte, error) {
 }
 
 // BridgeType is used for external adapters and has fields for
-// the name of the adapter and its URL.
+// the name of the adapter, its URL, and the account that owns it.
 type BridgeType struct {
-	Name string `json:"name" storm:"id,unique"`
-	URL  WebURL `json:"url"`
+	Name  string `json:"name" storm:"id,unique"`
+	URL   WebURL `json:"url"`
+	Owner string `json:"owner,omitempty"`
 }
 
 // UnmarshalJSON parses the given input and updates the BridgeType
-// Name and URL.
+// Name, URL and Owner.
 func (bt *BridgeType) UnmarshalJSON(input []byte) error {
 	type Alias BridgeType
 	var aux Alias
@@ -210,5 +269,19 @@ func (bt *BridgeType) UnmarshalJSON(input []byte) error {
 	}
 	bt.Name = strings.ToLower(aux.Name)
 	bt.URL = aux.URL
+	bt.Owner = aux.Owner
 	return nil
 }
+
+// JobSpecError is a non-fatal error attributable to a job, such as a failing
+// log subscription or task params its adapter cannot parse. Recurrences of
+// the same error increment its Occurrences rather than adding a record, so
+// that misconfigured jobs stand out until the error is dismissed.
+type JobSpecError struct {
+	ID          string    `json:"id" storm:"id,unique"`
+	JobID       string    `json:"jobId" storm:"index"`
+	Description string    `json:"description"`
+	Occurrences uint      `json:"occurrences"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
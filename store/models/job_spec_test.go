@@ -121,3 +121,72 @@ func TestTaskUnmarshalling(t *testing.T) {
 		})
 	}
 }
+
+func TestInitiatorUnmarshalling_Address(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		json        string
+		wantAddress string
+		wantENSName string
+		wantError   bool
+	}{
+		{"hex address", `{"type":"runlog","address":"0x3cCad4715152693fE3BC4460591e3D3Fbd071b42"}`,
+			"0x3cCad4715152693fE3BC4460591e3D3Fbd071b42", "", false},
+		{"ens name", `{"type":"runlog","address":"Oracle.Example.eth"}`,
+			"0x0000000000000000000000000000000000000000", "oracle.example.eth", false},
+		{"resolved ens name", `{"type":"runlog","address":"0x3cCad4715152693fE3BC4460591e3D3Fbd071b42","ensName":"oracle.example.eth"}`,
+			"0x3cCad4715152693fE3BC4460591e3D3Fbd071b42", "oracle.example.eth", false},
+		{"no address", `{"type":"web"}`,
+			"0x0000000000000000000000000000000000000000", "", false},
+		{"invalid", `{"type":"runlog","address":"0x3cCad47"}`, "", "", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var initr models.Initiator
+			err := json.Unmarshal([]byte(test.json), &initr)
+			if test.wantError {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantAddress, initr.Address.Hex())
+			assert.Equal(t, test.wantENSName, initr.ENSName)
+		})
+	}
+}
+
+func TestJobSpec_UnmarshalPriority(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		json      string
+		want      models.Priority
+		wantLevel int
+		wantError bool
+	}{
+		{"unset", `{}`, "", 1, false},
+		{"high", `{"priority":"high"}`, models.PriorityHigh, 2, false},
+		{"mixed case", `{"priority":"Low"}`, models.PriorityLow, 0, false},
+		{"unknown", `{"priority":"urgent"}`, "", 0, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			var j models.JobSpec
+			err := json.Unmarshal([]byte(test.json), &j)
+			if test.wantError {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.want, j.Priority)
+			assert.Equal(t, test.wantLevel, j.Priority.Level())
+		})
+	}
+}
@@ -2,16 +2,87 @@ package models
 
 import (
 	"log"
+
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
 )
 
+// allModels lists every type persisted by the ORM.
+func allModels() []interface{} {
+	return []interface{}{
+		&JobSpec{},
+		&JobRun{},
+		&Initiator{},
+		&Tx{},
+		&TxAttempt{},
+		&BridgeType{},
+		&IndexableBlockNumber{},
+		&APIToken{},
+		&User{},
+		&JobSpecError{},
+		&RunCost{},
+		&HeadSample{},
+	}
+}
+
 func (orm ORM) migrate() {
-	orm.initializeModel(&JobSpec{})
-	orm.initializeModel(&JobRun{})
-	orm.initializeModel(&Initiator{})
-	orm.initializeModel(&Tx{})
-	orm.initializeModel(&TxAttempt{})
-	orm.initializeModel(&BridgeType{})
-	orm.initializeModel(&IndexableBlockNumber{})
+	for _, model := range allModels() {
+		orm.initializeModel(model)
+	}
+	if err := orm.backfillInitiatorIDs(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// backfillInitiatorIDs copies the IDs of saved Initiators onto their copies
+// embedded in each JobSpec, which jobs saved before MarkRan was added left
+// at zero. Initiators were saved in the order they are listed, so the
+// unassigned ones of each type are matched in ID order.
+func (orm ORM) backfillInitiatorIDs() error {
+	var jobs []JobSpec
+	if err := orm.All(&jobs); err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		if !missingInitiatorIDs(job) {
+			continue
+		}
+		var saved []Initiator
+		err := orm.Select(q.Eq("JobID", job.ID)).OrderBy("ID").Find(&saved)
+		if err != nil && err != storm.ErrNotFound {
+			return err
+		}
+		assigned := map[int]bool{}
+		for _, initr := range job.Initiators {
+			assigned[initr.ID] = true
+		}
+		unassigned := map[string][]Initiator{}
+		for _, initr := range saved {
+			if !assigned[initr.ID] {
+				unassigned[initr.Type] = append(unassigned[initr.Type], initr)
+			}
+		}
+		for i, initr := range job.Initiators {
+			if initr.ID != 0 || len(unassigned[initr.Type]) == 0 {
+				continue
+			}
+			job.Initiators[i].ID = unassigned[initr.Type][0].ID
+			unassigned[initr.Type] = unassigned[initr.Type][1:]
+		}
+		if err := orm.Save(&job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func missingInitiatorIDs(job JobSpec) bool {
+	for _, initr := range job.Initiators {
+		if initr.ID == 0 {
+			return true
+		}
+	}
+	return false
 }
 
 func (orm ORM) initializeModel(klass interface{}) {
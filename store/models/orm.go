@@ -1,11 +1,16 @@
 package models
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"math/big"
+	"os"
 	"path"
 	"reflect"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/asdine/storm"
 	"github.com/asdine/storm/q"
@@ -89,6 +94,99 @@ func (orm *ORM) JobRunsFor(jobID string) ([]JobRun, error) {
 	return runs, err
 }
 
+// RecordJobSpecError saves the error for the job, or increments the
+// occurrences of the job's error with the same description.
+func (orm *ORM) RecordJobSpecError(jobID, description string) (JobSpecError, error) {
+	jse := JobSpecError{}
+	tx, err := orm.Begin(true)
+	if err != nil {
+		return jse, err
+	}
+	defer tx.Rollback()
+	now := time.Now()
+	err = tx.Select(q.Eq("JobID", jobID), q.Eq("Description", description)).First(&jse)
+	if err == storm.ErrNotFound {
+		jse = JobSpecError{
+			ID:          utils.NewBytes32ID(),
+			JobID:       jobID,
+			Description: description,
+			CreatedAt:   now,
+		}
+	} else if err != nil {
+		return jse, err
+	}
+	jse.Occurrences++
+	jse.UpdatedAt = now
+	if err := tx.Save(&jse); err != nil {
+		return jse, err
+	}
+	return jse, tx.Commit()
+}
+
+// JobSpecErrorsFor returns the errors recorded for the job, the most
+// recently occurring first.
+func (orm *ORM) JobSpecErrorsFor(jobID string) ([]JobSpecError, error) {
+	errs := []JobSpecError{}
+	err := orm.Select(q.Eq("JobID", jobID)).OrderBy("UpdatedAt").Reverse().Find(&errs)
+	if err == storm.ErrNotFound {
+		return []JobSpecError{}, nil
+	}
+	return errs, err
+}
+
+// JobRunQuery holds the details of the log that initiated a JobRun, to
+// search JobRuns by. Empty fields are ignored.
+type JobRunQuery struct {
+	TxHash    common.Hash
+	RequestID common.Hash
+	Requester common.Address
+}
+
+// Empty returns true if there is nothing to search by.
+func (jrq JobRunQuery) Empty() bool {
+	return common.EmptyHash(jrq.TxHash) &&
+		common.EmptyHash(jrq.RequestID) &&
+		jrq.Requester == utils.ZeroAddress
+}
+
+// Matches returns true if the JobRun matches every field of the query.
+func (jrq JobRunQuery) Matches(jr JobRun) bool {
+	return (common.EmptyHash(jrq.TxHash) || jrq.TxHash == jr.TxHash) &&
+		(common.EmptyHash(jrq.RequestID) || jrq.RequestID == jr.RequestID) &&
+		(jrq.Requester == utils.ZeroAddress || jrq.Requester == jr.Requester)
+}
+
+// SearchJobRuns returns the JobRuns matching the query, sorted by their
+// created at time, newest first.
+func (orm *ORM) SearchJobRuns(jrq JobRunQuery) ([]JobRun, error) {
+	runs := []JobRun{}
+	var err error
+	switch {
+	case !common.EmptyHash(jrq.TxHash):
+		err = orm.Where("TxHash", jrq.TxHash, &runs)
+	case !common.EmptyHash(jrq.RequestID):
+		err = orm.Where("RequestID", jrq.RequestID, &runs)
+	case jrq.Requester != utils.ZeroAddress:
+		err = orm.Where("Requester", jrq.Requester, &runs)
+	default:
+		return runs, errors.New("Must search by tx hash, request ID or requester")
+	}
+	if err != nil {
+		return runs, err
+	}
+
+	matches := []JobRun{}
+	for _, jr := range runs {
+		if jrq.Matches(jr) {
+			matches = append(matches, jr)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].CreatedAt.After(matches[j].CreatedAt)
+	})
+	return matches, nil
+}
+
 // SaveJob saves a job to the database.
 func (orm *ORM) SaveJob(job *JobSpec) error {
 	tx, err := orm.Begin(true)
@@ -97,10 +195,9 @@ func (orm *ORM) SaveJob(job *JobSpec) error {
 	}
 	defer tx.Rollback()
 
-	for i, initr := range job.Initiators {
+	for i := range job.Initiators {
 		job.Initiators[i].JobID = job.ID
-		initr.JobID = job.ID
-		if err := tx.Save(&initr); err != nil {
+		if err := tx.Save(&job.Initiators[i]); err != nil {
 			return err
 		}
 	}
@@ -110,6 +207,57 @@ func (orm *ORM) SaveJob(job *JobSpec) error {
 	return tx.Commit()
 }
 
+// MarkRan saves the JobRun and records that the initiator has fired, both on
+// the Initiator and on its copy within the JobSpec, in one transaction. It
+// returns an error if the initiator had already been marked, so that a one
+// time initiator starts at most one run.
+func (orm *ORM) MarkRan(initr Initiator, jr *JobRun) error {
+	tx, err := orm.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var stored Initiator
+	if err := tx.One("ID", initr.ID, &stored); err != nil {
+		return fmt.Errorf("MarkRan: initiator %v: %v", initr.ID, err)
+	} else if stored.Ran {
+		return fmt.Errorf("MarkRan: initiator %v for job %v has already run", initr.ID, initr.JobID)
+	}
+	stored.Ran = true
+	if err := tx.Save(&stored); err != nil {
+		return err
+	}
+
+	var job JobSpec
+	if err := tx.One("ID", stored.JobID, &job); err != nil {
+		return err
+	}
+	for i, ji := range job.Initiators {
+		if ji.ID == stored.ID {
+			job.Initiators[i].Ran = true
+		}
+	}
+	if err := tx.Save(&job); err != nil {
+		return err
+	}
+	if err := tx.Save(jr); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UnstartedJobRuns returns the JobRuns which were saved but never began
+// executing.
+func (orm *ORM) UnstartedJobRuns() ([]JobRun, error) {
+	runs := []JobRun{}
+	err := orm.Select(q.Eq("Status", "")).Find(&runs)
+	if err == storm.ErrNotFound {
+		return []JobRun{}, nil
+	}
+	return runs, err
+}
+
 // PendingJobRuns returns the JobRuns which have a status of "pending".
 func (orm *ORM) PendingJobRuns() ([]JobRun, error) {
 	runs := []JobRun{}
@@ -117,6 +265,58 @@ func (orm *ORM) PendingJobRuns() ([]JobRun, error) {
 	return runs, err
 }
 
+// Stats holds the size and contents of the database.
+type Stats struct {
+	Counts                    map[string]int `json:"counts"`
+	FileSize                  int64          `json:"fileSize"`
+	FreePageRatio             float64        `json:"freePageRatio"`
+	OldestPendingRunCreatedAt *time.Time     `json:"oldestPendingRunCreatedAt"`
+	HeadTimings               HeadTimings    `json:"headTimings"`
+}
+
+// Stats returns the number of records of each model, the size of the Bolt
+// file and the share of its pages that are free, along with the creation
+// time of the oldest pending JobRun, if any, and the timings of the heads
+// last received.
+func (orm *ORM) Stats() (Stats, error) {
+	stats := Stats{Counts: map[string]int{}}
+	for _, model := range allModels() {
+		count, err := orm.Count(model)
+		if err != nil {
+			return stats, err
+		}
+		stats.Counts[reflect.TypeOf(model).Elem().Name()] = count
+	}
+
+	info, err := os.Stat(orm.Bolt.Path())
+	if err != nil {
+		return stats, err
+	}
+	stats.FileSize = info.Size()
+	pages := stats.FileSize / int64(orm.Bolt.Info().PageSize)
+	if pages > 0 {
+		stats.FreePageRatio = float64(orm.Bolt.Stats().FreePageN) / float64(pages)
+	}
+
+	pending, err := orm.PendingJobRuns()
+	if err != nil {
+		return stats, err
+	}
+	for _, jr := range pending {
+		if stats.OldestPendingRunCreatedAt == nil || jr.CreatedAt.Before(*stats.OldestPendingRunCreatedAt) {
+			createdAt := jr.CreatedAt
+			stats.OldestPendingRunCreatedAt = &createdAt
+		}
+	}
+
+	samples, err := orm.HeadSamples()
+	if err != nil {
+		return stats, err
+	}
+	stats.HeadTimings = SummarizeHeadSamples(samples)
+	return stats, nil
+}
+
 // CreateTx saves the properties of an Ethereum transaction to the database.
 func (orm *ORM) CreateTx(
 	from common.Address,
@@ -203,9 +403,118 @@ func (orm *ORM) AddAttempt(
 	return attempt, dbtx.Commit()
 }
 
+// RunCosts returns the costs of every fulfillment, oldest first.
+func (orm *ORM) RunCosts() ([]RunCost, error) {
+	costs := []RunCost{}
+	err := orm.Select().OrderBy("CreatedAt").Find(&costs)
+	if err == storm.ErrNotFound {
+		return []RunCost{}, nil
+	}
+	return costs, err
+}
+
+// RunCostsFor returns the costs of the job's fulfillments, oldest first.
+func (orm *ORM) RunCostsFor(jobID string) ([]RunCost, error) {
+	costs := []RunCost{}
+	err := orm.Select(q.Eq("JobID", jobID)).OrderBy("CreatedAt").Find(&costs)
+	if err == storm.ErrNotFound {
+		return []RunCost{}, nil
+	}
+	return costs, err
+}
+
+// SaveHeadSample saves the sample, deleting the oldest samples so that no
+// more than the given number are kept.
+func (orm *ORM) SaveHeadSample(sample *HeadSample, keep int) error {
+	tx, err := orm.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := tx.Save(sample); err != nil {
+		return err
+	}
+	count, err := tx.Count(&HeadSample{})
+	if err != nil {
+		return err
+	}
+	if count > keep {
+		var old []HeadSample
+		if err := tx.Select().OrderBy("ReceivedAt").Limit(count - keep).Find(&old); err != nil {
+			return err
+		}
+		for i := range old {
+			if err := tx.DeleteStruct(&old[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// HeadSamples returns the head samples kept, oldest first.
+func (orm *ORM) HeadSamples() ([]HeadSample, error) {
+	samples := []HeadSample{}
+	err := orm.Select().OrderBy("ReceivedAt").Find(&samples)
+	if err == storm.ErrNotFound {
+		return []HeadSample{}, nil
+	}
+	return samples, err
+}
+
 // BridgeTypeFor returns the BridgeType for a given name.
 func (orm *ORM) BridgeTypeFor(name string) (BridgeType, error) {
 	tt := BridgeType{}
 	err := orm.One("Name", strings.ToLower(name), &tt)
 	return tt, err
 }
+
+// FindAPIToken returns the APIToken for the given secret.
+func (orm *ORM) FindAPIToken(secret string) (APIToken, error) {
+	token := APIToken{}
+	err := orm.One("Hash", HashAPIToken(secret), &token)
+	return token, err
+}
+
+// FindUser looks up the account with the given name.
+func (orm *ORM) FindUser(name string) (User, error) {
+	user := User{}
+	err := orm.One("Name", name, &user)
+	return user, err
+}
+
+// Users returns all accounts, oldest first.
+func (orm *ORM) Users() ([]User, error) {
+	users := []User{}
+	err := orm.Select().OrderBy("CreatedAt").Find(&users)
+	if err == storm.ErrNotFound {
+		return []User{}, nil
+	}
+	return users, err
+}
+
+// DeleteUser removes the account along with the API tokens issued to it.
+func (orm *ORM) DeleteUser(user User) error {
+	tx, err := orm.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := tx.Select(q.Eq("Owner", user.Name)).Delete(&APIToken{}); err != nil && err != storm.ErrNotFound {
+		return err
+	}
+	if err := tx.DeleteStruct(&user); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// APITokens returns all tokens, oldest first.
+func (orm *ORM) APITokens() ([]APIToken, error) {
+	tokens := []APIToken{}
+	err := orm.Select().OrderBy("CreatedAt").Find(&tokens)
+	if err == storm.ErrNotFound {
+		return []APIToken{}, nil
+	}
+	return tokens, err
+}
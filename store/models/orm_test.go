@@ -2,9 +2,12 @@ package models_test
 
 import (
 	"encoding/hex"
+	"io/ioutil"
 	"math/big"
 	"net/url"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
@@ -79,6 +82,91 @@ func TestPendingJobRuns(t *testing.T) {
 	assert.NotContains(t, pendingIDs, npr.ID)
 }
 
+func TestORMStats(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	stats, err := store.Stats()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, stats.Counts["JobRun"])
+	assert.Nil(t, stats.OldestPendingRunCreatedAt)
+	assert.True(t, stats.FileSize > 0)
+
+	j := models.NewJob()
+	assert.Nil(t, store.SaveJob(&j))
+	older := j.NewRun()
+	older.Status = models.StatusPending
+	older.CreatedAt = older.CreatedAt.Add(-time.Hour)
+	assert.Nil(t, store.Save(&older))
+	newer := j.NewRun()
+	newer.Status = models.StatusPending
+	assert.Nil(t, store.Save(&newer))
+	completed := j.NewRun()
+	completed.Status = models.StatusCompleted
+	completed.CreatedAt = older.CreatedAt.Add(-time.Hour)
+	assert.Nil(t, store.Save(&completed))
+
+	stats, err = store.Stats()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, stats.Counts["JobSpec"])
+	assert.Equal(t, 3, stats.Counts["JobRun"])
+	assert.True(t, older.CreatedAt.Equal(*stats.OldestPendingRunCreatedAt))
+	assert.True(t, stats.FreePageRatio >= 0 && stats.FreePageRatio <= 1)
+}
+
+func TestSearchJobRuns(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	txHash := common.HexToHash("0xabc")
+	requestID := common.HexToHash("0x1")
+	requester := cltest.NewAddress()
+
+	j := models.NewJob()
+	assert.Nil(t, store.SaveJob(&j))
+	first := j.NewRun()
+	first.TxHash = txHash
+	first.RequestID = requestID
+	first.Requester = requester
+	assert.Nil(t, store.Save(&first))
+	second := j.NewRun()
+	second.RequestID = common.HexToHash("0x2")
+	second.Requester = requester
+	second.CreatedAt = first.CreatedAt.Add(time.Second)
+	assert.Nil(t, store.Save(&second))
+	other := j.NewRun()
+	assert.Nil(t, store.Save(&other))
+
+	tests := []struct {
+		name  string
+		query models.JobRunQuery
+		want  []string
+	}{
+		{"tx hash", models.JobRunQuery{TxHash: txHash}, []string{first.ID}},
+		{"request ID", models.JobRunQuery{RequestID: requestID}, []string{first.ID}},
+		{"requester", models.JobRunQuery{Requester: requester}, []string{second.ID, first.ID}},
+		{"requester and request ID", models.JobRunQuery{Requester: requester, RequestID: second.RequestID}, []string{second.ID}},
+		{"no match", models.JobRunQuery{TxHash: common.HexToHash("0xdef")}, []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runs, err := store.SearchJobRuns(test.query)
+			assert.Nil(t, err)
+			ids := []string{}
+			for _, jr := range runs {
+				ids = append(ids, jr.ID)
+			}
+			assert.Equal(t, test.want, ids)
+		})
+	}
+
+	_, err := store.SearchJobRuns(models.JobRunQuery{})
+	assert.NotNil(t, err)
+}
+
 func TestCreatingTx(t *testing.T) {
 	store, cleanup := cltest.NewStore()
 	defer cleanup()
@@ -140,3 +228,117 @@ func TestBridgeTypeFor(t *testing.T) {
 		})
 	}
 }
+
+func TestORM_RecordJobSpecError(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	job := models.NewJob()
+	other := models.NewJob()
+
+	first, err := store.RecordJobSpecError(job.ID, "Error in log subscription: boom")
+	assert.Nil(t, err)
+	assert.Equal(t, uint(1), first.Occurrences)
+	again, err := store.RecordJobSpecError(job.ID, "Error in log subscription: boom")
+	assert.Nil(t, err)
+	assert.Equal(t, first.ID, again.ID)
+	assert.Equal(t, uint(2), again.Occurrences)
+	_, err = store.RecordJobSpecError(job.ID, "Invalid params for httpget task")
+	assert.Nil(t, err)
+	_, err = store.RecordJobSpecError(other.ID, "Error in log subscription: boom")
+	assert.Nil(t, err)
+
+	errs, err := store.JobSpecErrorsFor(job.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "Invalid params for httpget task", errs[0].Description)
+	assert.Equal(t, uint(2), errs[1].Occurrences)
+
+	errs, err = store.JobSpecErrorsFor("none")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(errs))
+}
+
+func TestORM_SaveHeadSample(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		sample := models.HeadSample{Number: uint64(i), ReceivedAt: start.Add(time.Duration(i) * time.Second)}
+		assert.Nil(t, store.SaveHeadSample(&sample, 3))
+	}
+
+	samples, err := store.HeadSamples()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(samples))
+	assert.Equal(t, uint64(1), samples[0].Number)
+	assert.Equal(t, uint64(3), samples[2].Number)
+}
+
+func TestNewORM_BackfillsInitiatorIDs(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "chainlink_orm")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	orm := models.NewORM(dir)
+	job := models.NewJob()
+	job.Initiators = []models.Initiator{
+		{Type: models.InitiatorWeb},
+		{Type: models.InitiatorRunAt, Time: models.Time{Time: time.Now()}},
+	}
+	// Jobs used to be saved with copies of their initiators, leaving the
+	// embedded IDs at zero.
+	for _, initr := range job.Initiators {
+		initr.JobID = job.ID
+		assert.Nil(t, orm.Save(&initr))
+	}
+	assert.Nil(t, orm.Save(&job))
+	assert.Nil(t, orm.Close())
+
+	orm = models.NewORM(dir)
+	defer orm.Close()
+
+	j, err := orm.FindJob(job.ID)
+	assert.Nil(t, err)
+	for _, initr := range j.Initiators {
+		var saved models.Initiator
+		assert.NotEqual(t, 0, initr.ID)
+		assert.Nil(t, orm.One("ID", initr.ID, &saved))
+		assert.Equal(t, initr.Type, saved.Type)
+	}
+
+	run := j.NewRun()
+	assert.Nil(t, orm.MarkRan(j.Initiators[1], &run))
+	j, err = orm.FindJob(job.ID)
+	assert.Nil(t, err)
+	assert.True(t, j.Initiators[1].Ran)
+}
+
+func TestUnstartedJobRuns(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	j := models.NewJob()
+	assert.Nil(t, store.SaveJob(&j))
+	unstarted := j.NewRun()
+	assert.Nil(t, store.Save(&unstarted))
+
+	pending := j.NewRun()
+	pending.Status = models.StatusPending
+	assert.Nil(t, store.Save(&pending))
+
+	runs, err := store.UnstartedJobRuns()
+	assert.Nil(t, err)
+	ids := []string{}
+	for _, jr := range runs {
+		ids = append(ids, jr.ID)
+	}
+
+	assert.Contains(t, ids, unstarted.ID)
+	assert.NotContains(t, ids, pending.ID)
+}
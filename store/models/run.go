@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/tidwall/gjson"
 	null "gopkg.in/guregu/null.v3"
 )
@@ -11,14 +12,31 @@ import (
 // JobRun tracks the status of a job by holding its TaskRuns and the
 // Result of each Run.
 type JobRun struct {
-	ID          string    `json:"id" storm:"id,unique"`
-	JobID       string    `json:"jobId" storm:"index"`
-	Status      string    `json:"status" storm:"index"`
-	Result      RunResult `json:"result" storm:"inline"`
-	TaskRuns    []TaskRun `json:"taskRuns" storm:"inline"`
-	CreatedAt   time.Time `json:"createdAt" storm:"index"`
-	CompletedAt null.Time `json:"completedAt"`
-}
+	ID          string         `json:"id" storm:"id,unique"`
+	JobID       string         `json:"jobId" storm:"index"`
+	Status      string         `json:"status" storm:"index"`
+	Result      RunResult      `json:"result" storm:"inline"`
+	TaskRuns    []TaskRun      `json:"taskRuns" storm:"inline"`
+	CreatedAt   time.Time      `json:"createdAt" storm:"index"`
+	CompletedAt null.Time      `json:"completedAt"`
+	TxHash      common.Hash    `json:"txHash" storm:"index"`
+	RequestID   common.Hash    `json:"requestId" storm:"index"`
+	Requester   common.Address `json:"requester" storm:"index"`
+	WakeOn      string         `json:"wakeOn,omitempty" storm:"index"`
+	WakeAt      null.Time      `json:"wakeAt"`
+	Priority    Priority       `json:"priority,omitempty"`
+}
+
+const (
+	// WakeOnHead resumes a pending JobRun on every new block, i.e. to check
+	// for transaction confirmations.
+	WakeOnHead = "head"
+	// WakeOnCallback leaves a pending JobRun until the external adapter
+	// reports back its result.
+	WakeOnCallback = "callback"
+	// WakeAtTime resumes a pending JobRun once WakeAt has passed.
+	WakeAtTime = "time"
+)
 
 // ForLogger formats the JobRun for a common formatting in the log.
 func (jr JobRun) ForLogger(kvs ...interface{}) []interface{} {
@@ -110,12 +128,16 @@ func (tr TaskRun) MergeTaskParams(j JSON) (TaskRun, error) {
 
 // RunResult keeps track of the outcome of a TaskRun. It stores
 // the Data and ErrorMessage, if any of either, and contains
-// a Pending field to track the status.
+// a Pending field to track the status. The IdempotencyKey identifies the
+// TaskRun an adapter is performing, so that external adapters can recognize
+// a request being retried. It is only set on an adapter's input, and is
+// never saved.
 type RunResult struct {
-	JobRunID     string      `json:"jobRunId"`
-	Data         JSON        `json:"data"`
-	ErrorMessage null.String `json:"error"`
-	Pending      bool        `json:"pending"`
+	JobRunID       string      `json:"jobRunId"`
+	Data           JSON        `json:"data"`
+	ErrorMessage   null.String `json:"error"`
+	Pending        bool        `json:"pending"`
+	IdempotencyKey string      `json:"idempotencyKey,omitempty"`
 }
 
 // WithValue returns a copy of the RunResult, overriding the "value" field of
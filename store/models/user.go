@@ -0,0 +1,76 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// User is an operator account on the node. Accounts with the admin role
+// see and manage everything, while accounts with a restricted role only see
+// the jobs and bridges they own, and can only do what their role permits.
+type User struct {
+	Name           string    `json:"name" storm:"id,unique"`
+	Role           Scope     `json:"role"`
+	HashedPassword string    `json:"-"`
+	Salt           string    `json:"-"`
+	CreatedAt      time.Time `json:"createdAt" storm:"index"`
+}
+
+// NewUser returns an account with the given role, storing only the salted
+// hash of the password.
+func NewUser(name, password string, role Scope) (User, error) {
+	if name == "" || password == "" {
+		return User{}, errors.New("Name and password are required")
+	} else if _, ok := scopeLevels[role]; !ok {
+		return User{}, fmt.Errorf("Unknown role %v", role)
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return User{}, err
+	}
+	salt := hex.EncodeToString(b)
+	hashed, err := hashPassword(password, salt)
+	if err != nil {
+		return User{}, err
+	}
+	return User{
+		Name:           name,
+		Role:           role,
+		HashedPassword: hashed,
+		Salt:           salt,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+// CheckPassword returns true if the given password is the account's.
+func (u User) CheckPassword(password string) bool {
+	hashed, err := hashPassword(password, u.Salt)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashed), []byte(u.HashedPassword)) == 1
+}
+
+// Restricted returns true if the account may only see what it owns.
+func (u User) Restricted() bool {
+	return u.Role != ScopeAdmin
+}
+
+// Owns returns true if the account may see a record with the given owner.
+func (u User) Owns(owner string) bool {
+	return !u.Restricted() || owner == u.Name
+}
+
+func hashPassword(password, salt string) (string, error) {
+	key, err := scrypt.Key([]byte(password), []byte(salt), 16384, 8, 1, 32)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(key), nil
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/chainlink/logger"
@@ -43,7 +44,9 @@ func ShowEthBalance(store *store.Store) (string, error) {
 // JobSpec holds the JobSpec definition and each run associated with that Job.
 type JobSpec struct {
 	models.JobSpec
-	Runs []models.JobRun `json:"runs,omitempty"`
+	Runs   []models.JobRun       `json:"runs,omitempty"`
+	Errors []models.JobSpecError `json:"errors,omitempty"`
+	Costs  *models.JobCosts      `json:"costs,omitempty"`
 }
 
 // MarshalJSON returns the JSON data of the Job and its Initiators.
@@ -144,17 +147,21 @@ func (i Initiator) MarshalJSON() ([]byte, error) {
 		return json.Marshal(&struct {
 			Type    string         `json:"type"`
 			Address common.Address `json:"address"`
+			ENSName string         `json:"ensName,omitempty"`
 		}{
 			models.InitiatorEthLog,
 			i.Address,
+			i.ENSName,
 		})
 	case models.InitiatorRunLog:
 		return json.Marshal(&struct {
 			Type    string         `json:"type"`
 			Address common.Address `json:"address"`
+			ENSName string         `json:"ensName,omitempty"`
 		}{
 			models.InitiatorRunLog,
 			i.Address,
+			i.ENSName,
 		})
 	default:
 		return nil, fmt.Errorf("Cannot marshal unsupported initiator type %v", i.Type)
@@ -198,3 +205,45 @@ func (t TaskSpec) FriendlyParams() (string, string) {
 	})
 	return strings.Join(keys, "\n"), strings.Join(values, "\n")
 }
+
+// StoreStats holds the database statistics along with the age of the oldest
+// pending JobRun, and the executions of each adapter type overall and per
+// job.
+type StoreStats struct {
+	models.Stats
+	OldestPendingRunAge string         `json:"oldestPendingRunAge,omitempty"`
+	Adapters            []AdapterStats `json:"adapters"`
+	AdaptersByJob       []AdapterStats `json:"adaptersByJob"`
+}
+
+// NewStoreStats returns the StoreStats with the age of the oldest pending
+// run measured from now.
+func NewStoreStats(stats models.Stats, metrics *store.AdapterMetrics, now time.Time) StoreStats {
+	ss := StoreStats{
+		Stats:         stats,
+		Adapters:      NewAdapterStats(metrics.ByAdapter()),
+		AdaptersByJob: NewAdapterStats(metrics.ByJob()),
+	}
+	if stats.OldestPendingRunCreatedAt != nil {
+		ss.OldestPendingRunAge = now.Sub(*stats.OldestPendingRunCreatedAt).Round(time.Second).String()
+	}
+	return ss
+}
+
+// AdapterStats adds the average duration to the executions of an adapter.
+type AdapterStats struct {
+	store.AdapterStats
+	AverageDuration models.Duration `json:"averageDuration"`
+}
+
+// NewAdapterStats returns the AdapterStats for each of the given entries.
+func NewAdapterStats(entries []store.AdapterStats) []AdapterStats {
+	stats := make([]AdapterStats, len(entries))
+	for i, entry := range entries {
+		stats[i] = AdapterStats{
+			AdapterStats:    entry,
+			AverageDuration: models.Duration{Duration: entry.AverageDuration()},
+		}
+	}
+	return stats
+}
@@ -0,0 +1,164 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	nats "github.com/nats-io/go-nats"
+	"github.com/smartcontractkit/chainlink/logger"
+)
+
+const (
+	// EventRunStarted is published when a JobRun begins executing.
+	EventRunStarted = "run.started"
+	// EventRunPending is published when a JobRun stops to wait on an event.
+	EventRunPending = "run.pending"
+	// EventRunCompleted is published when a JobRun finishes successfully.
+	EventRunCompleted = "run.completed"
+	// EventRunErrored is published when a JobRun finishes with an error.
+	EventRunErrored = "run.errored"
+	// EventNewHead is published for every new block header received.
+	EventNewHead = "head.new"
+	// EventHeadLagging is published when the latest head falls behind the
+	// node's eth_blockNumber by more than ETH_HEAD_MAX_LAG.
+	EventHeadLagging = "head.lagging"
+	// EventHeadDisconnected is published once ETH_RECONNECT_ALERT_AFTER
+	// attempts to reconnect to ETH_URL have failed in a row.
+	EventHeadDisconnected = "head.disconnected"
+	// EventHeadReconnected is published when the node reconnects to ETH_URL
+	// after losing its connection.
+	EventHeadReconnected = "head.reconnected"
+)
+
+// Event is the envelope for everything sent to the message bus.
+type Event struct {
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Data      interface{} `json:"data"`
+}
+
+// NewEvent wraps the given data in an Event of the given type.
+func NewEvent(eventType string, data interface{}) Event {
+	return Event{Type: eventType, CreatedAt: time.Now(), Data: data}
+}
+
+// Publisher emits node activity to an external message bus so that
+// other systems can consume it without polling the API.
+type Publisher interface {
+	Publish(Event) error
+	Close() error
+}
+
+// NewPublisher returns the Publisher for the broker configured by
+// PUBLISHER_URL. The scheme selects the transport: nats:// or kafka://.
+// If no URL is configured, events are dropped.
+func NewPublisher(config Config) (Publisher, error) {
+	if config.PublisherURL == "" {
+		return NoOpPublisher{}, nil
+	}
+	u, err := url.Parse(config.PublisherURL)
+	if err != nil {
+		return nil, fmt.Errorf("Publisher: %v", err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "nats":
+		return NewNATSPublisher(config.PublisherURL, config.PublisherTopic)
+	case "kafka":
+		return NewKafkaPublisher(strings.Split(u.Host, ","), config.PublisherTopic)
+	default:
+		return nil, fmt.Errorf("Publisher: unsupported scheme %v", u.Scheme)
+	}
+}
+
+// NoOpPublisher discards all events.
+type NoOpPublisher struct{}
+
+// Publish does nothing.
+func (NoOpPublisher) Publish(Event) error { return nil }
+
+// Close does nothing.
+func (NoOpPublisher) Close() error { return nil }
+
+// NATSPublisher publishes events as JSON to a NATS subject.
+type NATSPublisher struct {
+	conn    *nats.Conn
+	subject string
+}
+
+// NewNATSPublisher connects to the NATS server at the given URL.
+func NewNATSPublisher(url, subject string) (*NATSPublisher, error) {
+	conn, err := nats.Connect(url)
+	if err != nil {
+		return nil, fmt.Errorf("NATSPublisher: %v", err)
+	}
+	return &NATSPublisher{conn: conn, subject: subject}, nil
+}
+
+// Publish sends the JSON encoded event to the configured subject.
+func (np *NATSPublisher) Publish(event Event) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return np.conn.Publish(np.subject, b)
+}
+
+// Close flushes and closes the NATS connection.
+func (np *NATSPublisher) Close() error {
+	np.conn.Close()
+	return nil
+}
+
+// KafkaPublisher publishes events as JSON to a Kafka topic. Events are
+// sent in the background, and dropped while the producer's buffer is full.
+type KafkaPublisher struct {
+	producer sarama.AsyncProducer
+	topic    string
+}
+
+// NewKafkaPublisher creates a producer for the given list of brokers.
+func NewKafkaPublisher(brokers []string, topic string) (*KafkaPublisher, error) {
+	producer, err := sarama.NewAsyncProducer(brokers, sarama.NewConfig())
+	if err != nil {
+		return nil, fmt.Errorf("KafkaPublisher: %v", err)
+	}
+	kp := &KafkaPublisher{producer: producer, topic: topic}
+	go kp.logErrors()
+	return kp, nil
+}
+
+// Publish queues the JSON encoded event for the configured topic, keyed by
+// the event type, returning an error if it had to be dropped.
+func (kp *KafkaPublisher) Publish(event Event) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	msg := &sarama.ProducerMessage{
+		Topic: kp.topic,
+		Key:   sarama.StringEncoder(event.Type),
+		Value: sarama.ByteEncoder(b),
+	}
+	select {
+	case kp.producer.Input() <- msg:
+		return nil
+	default:
+		return errors.New("KafkaPublisher: buffer full, dropping event")
+	}
+}
+
+func (kp *KafkaPublisher) logErrors() {
+	for err := range kp.producer.Errors() {
+		logger.Warnw("Error publishing event to Kafka", "topic", kp.topic, "err", err.Err)
+	}
+}
+
+// Close shuts down the Kafka producer.
+func (kp *KafkaPublisher) Close() error {
+	return kp.producer.Close()
+}
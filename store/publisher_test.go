@@ -0,0 +1,37 @@
+package store_test
+
+import (
+	"testing"
+
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPublisher(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		wantNoOp  bool
+		wantError bool
+	}{
+		{"none", "", true, false},
+		{"unsupported scheme", "amqp://localhost:5672", false, true},
+		{"invalid url", "nats://%zz", false, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			config := strpkg.Config{PublisherURL: test.url, PublisherTopic: "chainlink"}
+			pub, err := strpkg.NewPublisher(config)
+			if test.wantError {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+			_, isNoOp := pub.(strpkg.NoOpPublisher)
+			assert.Equal(t, test.wantNoOp, isNoOp)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package store
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit allows Count requests every Interval.
+type RateLimit struct {
+	Count    int
+	Interval time.Duration
+}
+
+// RateLimits maps host names, optionally with a port, to their RateLimit.
+// The host "*" sets the limit of every host not listed.
+type RateLimits map[string]RateLimit
+
+// ParseRateLimits parses a comma separated list of limits in the form
+// host=count/interval, i.e. "api.example.com=10/1m,*=5/1s".
+func ParseRateLimits(str string) (RateLimits, error) {
+	limits := RateLimits{}
+	for _, entry := range strings.Split(str, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Rate limit %v is not in the form host=count/interval", entry)
+		}
+		limit := strings.SplitN(parts[1], "/", 2)
+		if len(limit) != 2 {
+			return nil, fmt.Errorf("Rate limit %v is not in the form host=count/interval", entry)
+		}
+		count, err := strconv.Atoi(limit[0])
+		if err != nil || count <= 0 {
+			return nil, fmt.Errorf("Rate limit %v must allow a positive number of requests", entry)
+		}
+		interval, err := time.ParseDuration(limit[1])
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("Rate limit %v must have a positive interval", entry)
+		}
+		limits[strings.ToLower(parts[0])] = RateLimit{Count: count, Interval: interval}
+	}
+	return limits, nil
+}
+
+// For returns the limit for the host, which may include a port, and false if
+// it is not limited. A limit for a host name without a port applies to
+// every port on it.
+func (rl RateLimits) For(host string) (RateLimit, bool) {
+	if limit, ok := rl[strings.ToLower(host)]; ok {
+		return limit, true
+	}
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		if limit, ok := rl[strings.ToLower(name)]; ok {
+			return limit, true
+		}
+	}
+	limit, ok := rl["*"]
+	return limit, ok
+}
+
+// HostRateLimiter spaces out the outbound requests of all adapters to each
+// host, so that jobs sharing a data provider stay within its quota. Each
+// host gets a bucket of Count requests that refills over Interval. Requests
+// are queued for at most one Interval, and refused beyond that rather than
+// leaving runs waiting indefinitely.
+type HostRateLimiter struct {
+	limits  RateLimits
+	clock   AfterNower
+	buckets map[string]*rateBucket
+	mutex   sync.Mutex
+}
+
+type rateBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewHostRateLimiter returns a HostRateLimiter enforcing the given limits.
+func NewHostRateLimiter(limits RateLimits, clock AfterNower) *HostRateLimiter {
+	return &HostRateLimiter{
+		limits:  limits,
+		clock:   clock,
+		buckets: map[string]*rateBucket{},
+	}
+}
+
+// Wait blocks until a request to the host is allowed, returning an error if
+// the request is refused or done is closed first.
+func (hrl *HostRateLimiter) Wait(host string, done <-chan struct{}) error {
+	delay, err := hrl.Reserve(host)
+	if err != nil || delay <= 0 {
+		return err
+	}
+	select {
+	case <-hrl.clock.After(delay):
+		return nil
+	case <-done:
+		return fmt.Errorf("Rate limited request to %v cancelled", host)
+	}
+}
+
+// Reserve takes a request from the host's bucket, returning how long the
+// caller must wait before making it, or an error without taking it if the
+// wait would exceed the limit's Interval.
+func (hrl *HostRateLimiter) Reserve(host string) (time.Duration, error) {
+	limit, ok := hrl.limits.For(host)
+	if !ok {
+		return 0, nil
+	}
+	count, interval := float64(limit.Count), float64(limit.Interval)
+
+	hrl.mutex.Lock()
+	defer hrl.mutex.Unlock()
+	now := hrl.clock.Now()
+	key := strings.ToLower(host)
+	bucket, ok := hrl.buckets[key]
+	if !ok {
+		bucket = &rateBucket{tokens: count, last: now}
+		hrl.buckets[key] = bucket
+	}
+	elapsed := now.Sub(bucket.last)
+	if elapsed > 0 {
+		bucket.tokens = math.Min(count, bucket.tokens+float64(elapsed)*count/interval)
+		bucket.last = now
+	}
+	bucket.tokens--
+	if bucket.tokens >= 0 {
+		return 0, nil
+	}
+	delay := time.Duration(-bucket.tokens * interval / count)
+	if delay > limit.Interval {
+		bucket.tokens++
+		return 0, fmt.Errorf("Rate limit for %v exceeded, refusing request", host)
+	}
+	return delay, nil
+}
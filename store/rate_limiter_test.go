@@ -0,0 +1,118 @@
+package store_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		input     string
+		want      strpkg.RateLimits
+		wantError bool
+	}{
+		{"empty", "", strpkg.RateLimits{}, false},
+		{"hosts", "API.example.com=10/1m, *=5/1s", strpkg.RateLimits{
+			"api.example.com": {Count: 10, Interval: time.Minute},
+			"*":               {Count: 5, Interval: time.Second},
+		}, false},
+		{"missing interval", "api.example.com=10", nil, true},
+		{"zero count", "api.example.com=0/1s", nil, true},
+		{"bad interval", "api.example.com=1/soon", nil, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			limits, err := strpkg.ParseRateLimits(test.input)
+			if test.wantError {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, test.want, limits)
+			}
+		})
+	}
+}
+
+func TestRateLimits_For(t *testing.T) {
+	t.Parallel()
+	limits := strpkg.RateLimits{
+		"api.example.com":      {Count: 1, Interval: time.Second},
+		"api.example.com:8080": {Count: 2, Interval: time.Second},
+	}
+
+	tests := []struct {
+		name   string
+		limits strpkg.RateLimits
+		host   string
+		want   int
+		wantOK bool
+	}{
+		{"host", limits, "API.example.com", 1, true},
+		{"host and port", limits, "api.example.com:8080", 2, true},
+		{"other port", limits, "api.example.com:9090", 1, true},
+		{"unlisted", limits, "other.example.com:8080", 0, false},
+		{"wildcard", strpkg.RateLimits{"*": {Count: 3, Interval: time.Second}}, "127.0.0.1:8080", 3, true},
+	}
+
+	for _, test := range tests {
+		limit, ok := test.limits.For(test.host)
+		assert.Equal(t, test.wantOK, ok, test.name)
+		assert.Equal(t, test.want, limit.Count, test.name)
+	}
+}
+
+func TestHostRateLimiter_Reserve(t *testing.T) {
+	t.Parallel()
+	start := time.Now()
+	clock := &cltest.SettableClock{}
+	clock.SetTime(start)
+	limits := strpkg.RateLimits{
+		"api.example.com": {Count: 2, Interval: time.Minute},
+	}
+	hrl := strpkg.NewHostRateLimiter(limits, clock)
+
+	tests := []struct {
+		name      string
+		at        time.Time
+		host      string
+		want      time.Duration
+		wantError bool
+	}{
+		{"first", start, "api.example.com", 0, false},
+		{"case insensitive", start, "API.example.com", 0, false},
+		{"empty bucket", start, "api.example.com", 30 * time.Second, false},
+		{"one interval queued", start, "api.example.com", time.Minute, false},
+		{"beyond one interval", start, "api.example.com", 0, true},
+		{"other host", start, "other.example.com", 0, false},
+		{"refilled", start.Add(2 * time.Minute), "api.example.com", 0, false},
+		{"refilled second", start.Add(2 * time.Minute), "api.example.com", 0, false},
+		{"refilled empty", start.Add(2 * time.Minute), "api.example.com", 30 * time.Second, false},
+	}
+
+	for _, test := range tests {
+		clock.SetTime(test.at)
+		delay, err := hrl.Reserve(test.host)
+		assert.Equal(t, test.wantError, err != nil, test.name)
+		assert.Equal(t, test.want, delay, test.name)
+	}
+}
+
+func TestHostRateLimiter_Wait_Cancelled(t *testing.T) {
+	t.Parallel()
+	clock := cltest.NewTriggerClock()
+	limits := strpkg.RateLimits{"*": {Count: 1, Interval: time.Minute}}
+	hrl := strpkg.NewHostRateLimiter(limits, clock)
+
+	done := make(chan struct{})
+	assert.Nil(t, hrl.Wait("api.example.com", done))
+	close(done)
+	assert.NotNil(t, hrl.Wait("api.example.com", done))
+}
@@ -0,0 +1,90 @@
+package store
+
+import (
+	"sync"
+	"time"
+)
+
+// ResponseCache holds the responses of external requests made by adapters,
+// so that tasks fetching the same resource in quick succession share one
+// request. Tasks asking for a response that is still being fetched wait for
+// that request rather than making their own.
+type ResponseCache struct {
+	entries  map[string]cacheEntry
+	inFlight map[string]*inFlightFetch
+	mutex    sync.Mutex
+}
+
+type cacheEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+type inFlightFetch struct {
+	done  chan struct{}
+	value string
+	err   error
+}
+
+// NewResponseCache returns an empty ResponseCache.
+func NewResponseCache() *ResponseCache {
+	return &ResponseCache{
+		entries:  map[string]cacheEntry{},
+		inFlight: map[string]*inFlightFetch{},
+	}
+}
+
+// Fetch returns the cached value for the key if there is one, and otherwise
+// calls fetch and caches its value for the TTL. Callers asking for a key
+// while it is being fetched wait for and share that result, errors
+// included, although errors are never cached.
+func (rc *ResponseCache) Fetch(key string, ttl time.Duration, clock AfterNower, fetch func() (string, error)) (string, error) {
+	rc.mutex.Lock()
+	if entry, ok := rc.entries[key]; ok && clock.Now().Before(entry.expiresAt) {
+		rc.mutex.Unlock()
+		return entry.value, nil
+	}
+	if f, ok := rc.inFlight[key]; ok {
+		rc.mutex.Unlock()
+		<-f.done
+		return f.value, f.err
+	}
+	f := &inFlightFetch{done: make(chan struct{})}
+	rc.inFlight[key] = f
+	rc.mutex.Unlock()
+
+	f.value, f.err = fetch()
+	if f.err == nil {
+		now := clock.Now()
+		rc.Set(key, f.value, now, now.Add(ttl))
+	}
+	rc.mutex.Lock()
+	delete(rc.inFlight, key)
+	rc.mutex.Unlock()
+	close(f.done)
+	return f.value, f.err
+}
+
+// Get returns the value stored for the key if it has not expired by now.
+func (rc *ResponseCache) Get(key string, now time.Time) (string, bool) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	entry, ok := rc.entries[key]
+	if !ok || !now.Before(entry.expiresAt) {
+		return "", false
+	}
+	return entry.value, true
+}
+
+// Set stores the value for the key until it expires, and removes any
+// entries that expired by now.
+func (rc *ResponseCache) Set(key, value string, now, expiresAt time.Time) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	for k, entry := range rc.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(rc.entries, k)
+		}
+	}
+	rc.entries[key] = cacheEntry{value: value, expiresAt: expiresAt}
+}
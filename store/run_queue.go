@@ -0,0 +1,181 @@
+package store
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// RunQueue executes runs on a fixed number of workers, so that a burst of
+// work cannot overwhelm the node. Waiting runs are taken in order of
+// priority, and a run is raised one priority level for every aging period
+// it has waited, so that low priority runs are never starved. A run is
+// executed by one worker at a time, so work added for a run that is already
+// executing waits until it finishes. A queue without workers executes every
+// run immediately and in the caller's goroutine.
+type RunQueue struct {
+	workers   int
+	aging     time.Duration
+	clock     AfterNower
+	items     []queuedRun
+	executing map[string]bool
+	followUps map[string][]func()
+	stopping  bool
+	cond      *sync.Cond
+	waitGroup sync.WaitGroup
+}
+
+type queuedRun struct {
+	id         string
+	priority   models.Priority
+	enqueuedAt time.Time
+	fns        []func()
+}
+
+// NewRunQueue returns a RunQueue with the given number of workers and
+// aging period. A zero aging period orders runs strictly by priority.
+func NewRunQueue(workers int, aging time.Duration, clock AfterNower) *RunQueue {
+	return &RunQueue{
+		workers:   workers,
+		aging:     aging,
+		clock:     clock,
+		executing: map[string]bool{},
+		followUps: map[string][]func(){},
+		cond:      sync.NewCond(&sync.Mutex{}),
+	}
+}
+
+// Start launches the workers.
+func (rq *RunQueue) Start() {
+	rq.cond.L.Lock()
+	rq.stopping = false
+	rq.cond.L.Unlock()
+	for i := 0; i < rq.workers; i++ {
+		rq.waitGroup.Add(1)
+		go rq.work()
+	}
+}
+
+// Stop waits for the workers to execute the runs still queued, and then
+// halts them. Runs enqueued from then on are rejected.
+func (rq *RunQueue) Stop() {
+	rq.cond.L.Lock()
+	rq.stopping = true
+	rq.cond.Broadcast()
+	rq.cond.L.Unlock()
+	rq.waitGroup.Wait()
+}
+
+// Enqueue adds the execution of the run with the given ID and priority to
+// the queue. If the run is already queued, fn is executed after the work
+// queued for it, and if it is executing, once it finishes.
+func (rq *RunQueue) Enqueue(id string, priority models.Priority, fn func()) error {
+	return rq.add(id, priority, fn, false)
+}
+
+// Wake adds the execution of the run with the given ID and priority to the
+// queue, like Enqueue, but for work that only resumes the run and is
+// therefore dropped if the run is already waiting to be executed.
+func (rq *RunQueue) Wake(id string, priority models.Priority, fn func()) error {
+	return rq.add(id, priority, fn, true)
+}
+
+func (rq *RunQueue) add(id string, priority models.Priority, fn func(), wakeup bool) error {
+	rq.cond.L.Lock()
+	if rq.stopping {
+		rq.cond.L.Unlock()
+		return fmt.Errorf("RunQueue: stopping, rejected run %v", id)
+	}
+	if rq.workers <= 0 {
+		rq.cond.L.Unlock()
+		fn()
+		return nil
+	}
+	defer rq.cond.L.Unlock()
+
+	if rq.executing[id] {
+		if !wakeup || len(rq.followUps[id]) == 0 {
+			rq.followUps[id] = append(rq.followUps[id], fn)
+		}
+		return nil
+	}
+	for i, item := range rq.items {
+		if item.id == id {
+			if !wakeup {
+				rq.items[i].fns = append(item.fns, fn)
+			}
+			return nil
+		}
+	}
+	rq.items = append(rq.items, queuedRun{
+		id:         id,
+		priority:   priority,
+		enqueuedAt: rq.clock.Now(),
+		fns:        []func(){fn},
+	})
+	rq.cond.Signal()
+	return nil
+}
+
+// Len returns the number of runs waiting for a worker.
+func (rq *RunQueue) Len() int {
+	rq.cond.L.Lock()
+	defer rq.cond.L.Unlock()
+	return len(rq.items)
+}
+
+func (rq *RunQueue) work() {
+	defer rq.waitGroup.Done()
+	for {
+		rq.cond.L.Lock()
+		for len(rq.items) == 0 && !rq.stopping {
+			rq.cond.Wait()
+		}
+		if len(rq.items) == 0 {
+			rq.cond.L.Unlock()
+			return
+		}
+		next := rq.next()
+		rq.executing[next.id] = true
+		rq.cond.L.Unlock()
+		for _, fn := range next.fns {
+			fn()
+		}
+
+		rq.cond.L.Lock()
+		delete(rq.executing, next.id)
+		if fns, ok := rq.followUps[next.id]; ok {
+			delete(rq.followUps, next.id)
+			next.enqueuedAt = rq.clock.Now()
+			next.fns = fns
+			rq.items = append(rq.items, next)
+			rq.cond.Signal()
+		}
+		rq.cond.L.Unlock()
+	}
+}
+
+// next removes and returns the most urgent queued run, the oldest first
+// among those of equal urgency. It must be called with the lock held.
+func (rq *RunQueue) next() queuedRun {
+	now := rq.clock.Now()
+	best := 0
+	for i := 1; i < len(rq.items); i++ {
+		if rq.urgency(rq.items[i], now) > rq.urgency(rq.items[best], now) {
+			best = i
+		}
+	}
+	item := rq.items[best]
+	rq.items = append(rq.items[:best], rq.items[best+1:]...)
+	return item
+}
+
+func (rq *RunQueue) urgency(item queuedRun, now time.Time) int {
+	urgency := item.priority.Level()
+	if rq.aging > 0 {
+		urgency += int(now.Sub(item.enqueuedAt) / rq.aging)
+	}
+	return urgency
+}
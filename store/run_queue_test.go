@@ -0,0 +1,139 @@
+package store_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunQueue_PriorityAndAging(t *testing.T) {
+	t.Parallel()
+	start := time.Now()
+	clock := &cltest.SettableClock{}
+	clock.SetTime(start)
+	rq := strpkg.NewRunQueue(1, time.Minute, clock)
+	rq.Start()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	rq.Enqueue("busy", models.PriorityHigh, func() {
+		close(started)
+		<-release
+	})
+	<-started
+
+	order := []string{}
+	record := func(name string) func() {
+		return func() { order = append(order, name) }
+	}
+	rq.Enqueue("1", models.PriorityLow, record("waiting low"))
+	clock.SetTime(start.Add(90 * time.Second))
+	rq.Enqueue("2", models.PriorityLow, record("low"))
+	rq.Enqueue("3", "", record("normal"))
+	rq.Enqueue("4", models.PriorityHigh, record("high"))
+	rq.Enqueue("4", models.PriorityHigh, record("high again"))
+	assert.Equal(t, 4, rq.Len())
+
+	close(release)
+	rq.Stop()
+	assert.Equal(t, []string{"high", "high again", "waiting low", "normal", "low"}, order)
+	assert.Equal(t, 0, rq.Len())
+}
+
+func TestRunQueue_WhileExecuting(t *testing.T) {
+	t.Parallel()
+	rq := strpkg.NewRunQueue(2, time.Minute, strpkg.Clock{})
+	rq.Start()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	order := make(chan string, 10)
+	record := func(name string) func() {
+		return func() { order <- name }
+	}
+	assert.Nil(t, rq.Enqueue("1", models.PriorityHigh, func() {
+		close(started)
+		<-release
+		order <- "first"
+	}))
+	<-started
+
+	assert.Nil(t, rq.Wake("1", models.PriorityHigh, record("wakeup")))
+	assert.Nil(t, rq.Wake("1", models.PriorityHigh, record("duplicate wakeup")))
+	assert.Nil(t, rq.Enqueue("1", models.PriorityHigh, record("with input")))
+	assert.Equal(t, 0, rq.Len())
+
+	close(release)
+	rq.Stop()
+	close(order)
+	ran := []string{}
+	for name := range order {
+		ran = append(ran, name)
+	}
+	assert.Equal(t, []string{"first", "wakeup", "with input"}, ran)
+}
+
+func TestRunQueue_Wake_DropsWhenQueued(t *testing.T) {
+	t.Parallel()
+	rq := strpkg.NewRunQueue(1, time.Minute, strpkg.Clock{})
+	rq.Start()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	rq.Enqueue("busy", models.PriorityHigh, func() {
+		close(started)
+		<-release
+	})
+	<-started
+
+	order := []string{}
+	record := func(name string) func() {
+		return func() { order = append(order, name) }
+	}
+	assert.Nil(t, rq.Enqueue("1", models.PriorityLow, record("with input")))
+	assert.Nil(t, rq.Wake("1", models.PriorityLow, record("wakeup")))
+	assert.Equal(t, 1, rq.Len())
+
+	close(release)
+	rq.Stop()
+	assert.Equal(t, []string{"with input"}, order)
+}
+
+func TestRunQueue_RejectsOnceStopped(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		workers int
+	}{
+		{"with workers", 1},
+		{"without workers", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rq := strpkg.NewRunQueue(test.workers, time.Minute, strpkg.Clock{})
+			rq.Start()
+			rq.Stop()
+
+			ran := false
+			assert.NotNil(t, rq.Enqueue("1", models.PriorityLow, func() { ran = true }))
+			assert.NotNil(t, rq.Wake("1", models.PriorityLow, func() { ran = true }))
+			assert.False(t, ran)
+			assert.Equal(t, 0, rq.Len())
+		})
+	}
+}
+
+func TestRunQueue_WithoutWorkers(t *testing.T) {
+	t.Parallel()
+	rq := strpkg.NewRunQueue(0, time.Minute, strpkg.Clock{})
+
+	ran := false
+	rq.Enqueue("1", models.PriorityLow, func() { ran = true })
+	assert.True(t, ran)
+}
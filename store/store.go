@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -21,7 +22,15 @@ type Store struct {
 	Exiter    func(int)
 	KeyStore  *KeyStore
 	TxManager *TxManager
+	Publisher Publisher
+	Cache     *ResponseCache
+	Metrics   *AdapterMetrics
+	RunQueue  *RunQueue
+	Limiter   *HostRateLimiter
+	Breaker   *CircuitBreaker
 	sigs      chan os.Signal
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
 type rpcSubscriptionWrapper struct {
@@ -46,27 +55,40 @@ func NewStore(config Config) *Store {
 		logger.Fatal(err)
 	}
 	keyStore := NewKeyStore(config.KeysDir())
+	publisher, err := NewPublisher(config)
+	if err != nil {
+		logger.Fatal(err)
+	}
 
+	clock := Clock{}
 	store := &Store{
-		ORM:      orm,
-		Config:   config,
-		KeyStore: keyStore,
-		Exiter:   os.Exit,
-		Clock:    Clock{},
+		ORM:       orm,
+		Config:    config,
+		KeyStore:  keyStore,
+		Exiter:    os.Exit,
+		Clock:     clock,
+		Publisher: publisher,
+		Cache:     NewResponseCache(),
+		Metrics:   NewAdapterMetrics(),
+		RunQueue:  NewRunQueue(config.RunQueueWorkers, config.RunQueueAging, clock),
+		Limiter:   NewHostRateLimiter(config.HTTPRateLimits, clock),
+		Breaker:   NewCircuitBreaker(config.HTTPCircuitFailures, config.HTTPCircuitCooldown, clock),
 		TxManager: &TxManager{
 			Config:    config,
-			EthClient: &EthClient{rpcSubscriptionWrapper{ethrpc}},
+			EthClient: &EthClient{CallerSubscriber: rpcSubscriptionWrapper{ethrpc}},
 			KeyStore:  keyStore,
 			ORM:       orm,
 		},
+		done: make(chan struct{}),
 	}
 	return store
 }
 
-// Start listens for interrupt signals from the operating system so
-// that the database can be properly closed before the application
-// exits.
+// Start launches the RunQueue's workers and listens for interrupt signals
+// from the operating system so that the database can be properly closed
+// before the application exits.
 func (s *Store) Start() {
+	s.RunQueue.Start()
 	s.sigs = make(chan os.Signal, 1)
 	signal.Notify(s.sigs, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
@@ -76,6 +98,31 @@ func (s *Store) Start() {
 	}()
 }
 
+// Close waits for the queued runs to execute, shuts down the connection to
+// the message bus and closes the database.
+func (s *Store) Close() error {
+	s.closeOnce.Do(func() { close(s.done) })
+	s.RunQueue.Stop()
+	if err := s.Publisher.Close(); err != nil {
+		logger.Warnw("Error closing publisher", "err", err)
+	}
+	return s.ORM.Close()
+}
+
+// Done returns a channel that is closed once the store begins closing, for
+// goroutines that must not outlive it.
+func (s *Store) Done() <-chan struct{} {
+	return s.done
+}
+
+// Publish sends the event to the configured message bus, logging rather
+// than returning failures so that node activity is never blocked on it.
+func (s *Store) Publish(eventType string, data interface{}) {
+	if err := s.Publisher.Publish(NewEvent(eventType, data)); err != nil {
+		logger.Warnw("Error publishing event", "type", eventType, "err", err)
+	}
+}
+
 // AfterNower is an interface that fulfills the `After()` and `Now()`
 // methods.
 type AfterNower interface {
@@ -29,6 +29,25 @@ func TestGracefulShutdown(t *testing.T) {
 	}).Should(BeTrue())
 }
 
+func TestStore_Close_ClosesDone(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	select {
+	case <-store.Done():
+		t.Fatal("done closed before the store")
+	default:
+	}
+
+	assert.Nil(t, store.Close())
+	select {
+	case <-store.Done():
+	default:
+		t.Fatal("done still open after closing the store")
+	}
+}
+
 func TestConfigDefaults(t *testing.T) {
 	config := strpkg.NewConfig()
 	assert.Equal(t, uint64(0), config.ChainID)
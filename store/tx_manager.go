@@ -3,6 +3,7 @@ package store
 import (
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -17,9 +18,11 @@ const defaultGasLimit uint64 = 500000
 // the local Config for the application, and the database.
 type TxManager struct {
 	*EthClient
-	KeyStore *KeyStore
-	Config   Config
-	ORM      *models.ORM
+	KeyStore      *KeyStore
+	Config        Config
+	ORM           *models.ORM
+	gasPrice      *big.Int
+	gasPriceMutex sync.RWMutex
 }
 
 // CreateTx signs and sends a transaction to the Ethereum blockchain.
@@ -45,8 +48,7 @@ func (txm *TxManager) CreateTx(to common.Address, data []byte) (*models.Tx, erro
 		return nil, err
 	}
 
-	gasPrice := &txm.Config.EthGasPriceDefault
-	_, err = txm.createAttempt(tx, gasPrice, blkNum)
+	_, err = txm.createAttempt(tx, txm.GasPrice(), blkNum)
 	if err != nil {
 		return tx, err
 	}
@@ -54,6 +56,49 @@ func (txm *TxManager) CreateTx(to common.Address, data []byte) (*models.Tx, erro
 	return tx, nil
 }
 
+// GasPrice returns the gas price used for new transactions. This is the
+// configured default until it has been refreshed from the node.
+func (txm *TxManager) GasPrice() *big.Int {
+	txm.gasPriceMutex.RLock()
+	defer txm.gasPriceMutex.RUnlock()
+	if txm.gasPrice == nil {
+		return new(big.Int).Set(&txm.Config.EthGasPriceDefault)
+	}
+	return new(big.Int).Set(txm.gasPrice)
+}
+
+// RefreshGasPrice updates the gas price used for new transactions from
+// eth_gasPrice, bounded by the configured floor and ceiling.
+func (txm *TxManager) RefreshGasPrice() error {
+	gasPrice, err := txm.GetGasPrice()
+	if err != nil {
+		return fmt.Errorf("TxManager#RefreshGasPrice: %v", err)
+	}
+	bounded := boundGasPrice(gasPrice, &txm.Config.EthGasPriceFloor, &txm.Config.EthGasPriceCeiling)
+
+	txm.gasPriceMutex.Lock()
+	changed := txm.gasPrice == nil || txm.gasPrice.Cmp(bounded) != 0
+	txm.gasPrice = bounded
+	txm.gasPriceMutex.Unlock()
+
+	if changed {
+		logger.Infow(fmt.Sprintf("Gas price updated to %v", bounded), "nodeGasPrice", gasPrice)
+	}
+	return nil
+}
+
+// boundGasPrice clamps the gas price between floor and ceiling. A zero
+// ceiling is treated as no ceiling.
+func boundGasPrice(gasPrice, floor, ceiling *big.Int) *big.Int {
+	if gasPrice.Cmp(floor) < 0 {
+		return new(big.Int).Set(floor)
+	}
+	if ceiling.Sign() > 0 && gasPrice.Cmp(ceiling) > 0 {
+		return new(big.Int).Set(ceiling)
+	}
+	return gasPrice
+}
+
 // EnsureTxConfirmed returns true if the given transaction hash has been
 // confirmed on the blockchain.
 func (txm *TxManager) EnsureTxConfirmed(hash common.Hash) (bool, error) {
@@ -82,6 +127,12 @@ func (txm *TxManager) EnsureTxConfirmed(hash common.Hash) (bool, error) {
 	return false, nil
 }
 
+// ResolveENSName returns the address the given name currently points to,
+// using the configured ENS registry.
+func (txm *TxManager) ResolveENSName(name string) (common.Address, error) {
+	return txm.EthClient.ResolveENSName(txm.Config.ENSRegistry(), name)
+}
+
 func (txm *TxManager) createAttempt(
 	tx *models.Tx,
 	gasPrice *big.Int,
@@ -151,6 +202,7 @@ func (txm *TxManager) handleConfirmed(
 		return false, nil
 	}
 
+	txat.GasUsed = uint64(rcpt.GasUsed)
 	if err := txm.ORM.ConfirmTx(tx, txat); err != nil {
 		return false, err
 	}
@@ -2,6 +2,7 @@ package store_test
 
 import (
 	"encoding/hex"
+	"math/big"
 	"testing"
 
 	"github.com/smartcontractkit/chainlink/internal/cltest"
@@ -176,3 +177,49 @@ func TestTxManager_EnsureTxConfirmed_WhenWithConfsButNotSafe(t *testing.T) {
 
 	ethMock.EnsureAllCalled(t)
 }
+
+func TestTxManager_RefreshGasPrice(t *testing.T) {
+	tests := []struct {
+		name      string
+		nodePrice string
+		want      *big.Int
+	}{
+		{"within bounds", "0x4a817c800", big.NewInt(20000000000)},
+		{"below floor", "0x3b9aca00", big.NewInt(5000000000)},
+		{"above ceiling", "0x174876e800", big.NewInt(50000000000)},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			config, cfgCleanup := cltest.NewConfig()
+			defer cfgCleanup()
+			config.EthGasPriceFloor = *big.NewInt(5000000000)
+			config.EthGasPriceCeiling = *big.NewInt(50000000000)
+			store, cleanup := cltest.NewStoreWithConfig(config)
+			defer cleanup()
+			ethMock := cltest.MockEthOnStore(store)
+			txm := store.TxManager
+
+			assert.Equal(t, &config.EthGasPriceDefault, txm.GasPrice())
+
+			ethMock.Register("eth_gasPrice", test.nodePrice)
+			assert.Nil(t, txm.RefreshGasPrice())
+			assert.Equal(t, test.want, txm.GasPrice())
+			ethMock.EnsureAllCalled(t)
+		})
+	}
+}
+
+func TestTxManager_RefreshGasPrice_Error(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	ethMock := cltest.MockEthOnStore(store)
+	txm := store.TxManager
+
+	ethMock.RegisterError("eth_gasPrice", "node unavailable")
+	assert.NotNil(t, txm.RefreshGasPrice())
+	assert.Equal(t, &store.Config.EthGasPriceDefault, txm.GasPrice())
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/jpillora/backoff"
 	uuid "github.com/satori/go.uuid"
@@ -107,6 +108,16 @@ func BasicAuthPatch(username, password, url string, contentType string, body io.
 	return resp, err
 }
 
+// BasicAuthDelete uses the given username and password to send a DELETE
+// request at the given URL and returns a response.
+func BasicAuthDelete(username, password, url string) (*http.Response, error) {
+	client := &http.Client{}
+	request, _ := http.NewRequest("DELETE", url, nil)
+	request.SetBasicAuth(username, password)
+	resp, err := client.Do(request)
+	return resp, err
+}
+
 // FormatJSON applies indent to format a JSON response.
 func FormatJSON(v interface{}) ([]byte, error) {
 	return json.MarshalIndent(v, "", "  ")
@@ -240,6 +251,25 @@ func ToFilterArg(q ethereum.FilterQuery) interface{} {
 	return arg
 }
 
+// ToParityFilterArg returns the filter arguments for a Parity logs
+// subscription. Parity rejects null topics and block range parameters on
+// eth_subscribe, so they are only included when set.
+func ToParityFilterArg(q ethereum.FilterQuery) interface{} {
+	arg := map[string]interface{}{
+		"address": q.Addresses,
+	}
+	if len(q.Topics) > 0 {
+		arg["topics"] = q.Topics
+	}
+	if q.FromBlock != nil {
+		arg["fromBlock"] = toBlockNumArg(q.FromBlock)
+	}
+	if q.ToBlock != nil {
+		arg["toBlock"] = toBlockNumArg(q.ToBlock)
+	}
+	return arg
+}
+
 func toBlockNumArg(number *big.Int) string {
 	if number == nil {
 		return "latest"
@@ -247,9 +277,25 @@ func toBlockNumArg(number *big.Int) string {
 	return hexutil.EncodeBig(number)
 }
 
+// ENSNamehash returns the node for the given name as defined by EIP-137,
+// which is the key used to look the name up in the ENS registry.
+func ENSNamehash(name string) common.Hash {
+	node := common.Hash{}
+	if name == "" {
+		return node
+	}
+	labels := strings.Split(strings.ToLower(name), ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		label := crypto.Keccak256([]byte(labels[i]))
+		node = common.BytesToHash(crypto.Keccak256(node.Bytes(), label))
+	}
+	return node
+}
+
 type Sleeper interface {
 	Reset()
 	Sleep()
+	After() <-chan time.Time
 	Duration() time.Duration
 }
 
@@ -268,6 +314,12 @@ func (bs BackoffSleeper) Sleep() {
 	time.Sleep(bs.Backoff.Duration())
 }
 
+// After returns a channel that receives once the next backoff has passed,
+// so that the wait can be abandoned.
+func (bs BackoffSleeper) After() <-chan time.Time {
+	return time.After(bs.Backoff.Duration())
+}
+
 func (bs BackoffSleeper) Duration() time.Duration {
 	return bs.ForAttempt(bs.Attempt())
 }
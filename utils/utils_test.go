@@ -97,6 +97,44 @@ func TestUtils_HexToString(t *testing.T) {
 	}
 }
 
+func TestUtils_ENSNamehash(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"", "0x0000000000000000000000000000000000000000000000000000000000000000"},
+		{"eth", "0x93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae"},
+		{"foo.eth", "0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f"},
+		{"FOO.eth", "0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, utils.ENSNamehash(test.name).Hex())
+		})
+	}
+}
+
+func TestUtils_ToParityFilterArg(t *testing.T) {
+	t.Parallel()
+	address := cltest.NewAddress()
+	topic := common.HexToHash("0x1")
+
+	arg := utils.ToParityFilterArg(utils.ToFilterQueryFor(nil, []common.Address{address})).(map[string]interface{})
+	assert.Equal(t, []common.Address{address}, arg["address"])
+	assert.NotContains(t, arg, "topics")
+	assert.NotContains(t, arg, "fromBlock")
+	assert.NotContains(t, arg, "toBlock")
+
+	q := utils.ToFilterQueryFor(big.NewInt(16), []common.Address{address})
+	q.Topics = [][]common.Hash{{topic}}
+	arg = utils.ToParityFilterArg(q).(map[string]interface{})
+	assert.Equal(t, q.Topics, arg["topics"])
+	assert.Equal(t, "0x10", arg["fromBlock"])
+	assert.NotContains(t, arg, "toBlock")
+}
+
 func TestUtils_BackoffSleeper(t *testing.T) {
 	bs := utils.NewBackoffSleeper()
 	d := 1 * time.Nanosecond
@@ -0,0 +1,107 @@
+package web
+
+import (
+	"fmt"
+
+	"github.com/asdine/storm"
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// APITokensController manages the long lived tokens used to access the API
+// without the operator's password.
+type APITokensController struct {
+	App *services.ChainlinkApplication
+}
+
+// APITokenRequest holds the parameters for creating an APIToken, optionally
+// on behalf of the account named as its owner.
+type APITokenRequest struct {
+	Scope models.Scope `json:"scope" binding:"required"`
+	Owner string       `json:"owner"`
+}
+
+// Index lists all of the APITokens, without their secrets.
+// Example:
+//  "<application>/api_tokens"
+func (atc *APITokensController) Index(c *gin.Context) {
+	if tokens, err := atc.App.Store.APITokens(); err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else {
+		c.JSON(200, gin.H{"tokens": tokens})
+	}
+}
+
+// Create generates a new APIToken with the requested scope, which may not
+// exceed the role of its owner. The token is only ever returned in this
+// response.
+// Example:
+//  "<application>/api_tokens"
+func (atc *APITokensController) Create(c *gin.Context) {
+	req := APITokenRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else if err := atc.validateOwner(req); err != nil {
+		c.JSON(400, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else if token, secret, err := models.NewAPIToken(req.Scope, req.Owner); err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else if err := atc.App.Store.Save(&token); err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else {
+		c.JSON(200, gin.H{
+			"id":        token.ID,
+			"scope":     token.Scope,
+			"owner":     token.Owner,
+			"createdAt": token.CreatedAt,
+			"token":     secret,
+		})
+	}
+}
+
+func (atc *APITokensController) validateOwner(req APITokenRequest) error {
+	if req.Owner == "" {
+		return nil
+	}
+	user, err := atc.App.Store.FindUser(req.Owner)
+	if err == storm.ErrNotFound {
+		return fmt.Errorf("Owner %v is not an account", req.Owner)
+	} else if err != nil {
+		return err
+	} else if !user.Role.Permits(req.Scope) {
+		return fmt.Errorf("Scope %v exceeds the %v role of %v", req.Scope, user.Role, user.Name)
+	}
+	return nil
+}
+
+// Destroy revokes the APIToken with the given ID.
+// Example:
+//  "<application>/api_tokens/:TokenID"
+func (atc *APITokensController) Destroy(c *gin.Context) {
+	token := models.APIToken{}
+	if err := atc.App.Store.One("ID", c.Param("TokenID"), &token); err == storm.ErrNotFound {
+		c.JSON(404, gin.H{
+			"errors": []string{"API token not found"},
+		})
+	} else if err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else if err := atc.App.Store.DeleteStruct(&token); err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else {
+		c.JSON(200, gin.H{"id": token.ID})
+	}
+}
@@ -0,0 +1,120 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type APITokenJSON struct {
+	ID    string `json:"id"`
+	Scope string `json:"scope"`
+	Token string `json:"token"`
+}
+
+func createAPIToken(t *testing.T, app *cltest.TestApplication, scope string) APITokenJSON {
+	resp := cltest.BasicAuthPost(
+		app.Server.URL+"/v2/api_tokens",
+		"application/json",
+		bytes.NewBufferString(`{"scope":"`+scope+`"}`),
+	)
+	cltest.CheckStatusCode(t, resp, 200)
+	var token APITokenJSON
+	assert.Nil(t, json.Unmarshal(cltest.ParseResponseBody(resp), &token))
+	return token
+}
+
+func TestAPITokensController_Create(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	token := createAPIToken(t, app, "run-trigger")
+	assert.Equal(t, "run-trigger", token.Scope)
+	assert.NotEmpty(t, token.Token)
+
+	saved, err := app.Store.FindAPIToken(token.Token)
+	assert.Nil(t, err)
+	assert.Equal(t, token.ID, saved.ID)
+	assert.Equal(t, models.ScopeRunTrigger, saved.Scope)
+	assert.NotEqual(t, token.Token, saved.Hash)
+}
+
+func TestAPITokensController_Create_InvalidScope(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	resp := cltest.BasicAuthPost(
+		app.Server.URL+"/v2/api_tokens",
+		"application/json",
+		bytes.NewBufferString(`{"scope":"superuser"}`),
+	)
+	cltest.CheckStatusCode(t, resp, 400)
+}
+
+func TestAPITokensController_Scopes(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	j := cltest.NewJobWithWebInitiator()
+	assert.Nil(t, app.Store.SaveJob(&j))
+	specs := app.Server.URL + "/v2/specs"
+	runs := specs + "/" + j.ID + "/runs"
+	tokens := app.Server.URL + "/v2/api_tokens"
+
+	tests := []struct {
+		scope      string
+		wantRead   int
+		wantRun    int
+		wantTokens int
+	}{
+		{"read-only", 200, 403, 403},
+		{"run-trigger", 200, 200, 403},
+		{"admin", 200, 200, 200},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scope, func(t *testing.T) {
+			token := createAPIToken(t, app, test.scope).Token
+
+			resp := cltest.TokenAuthRequest("GET", specs, token, nil)
+			cltest.CheckStatusCode(t, resp, test.wantRead)
+			resp = cltest.TokenAuthRequest("POST", runs, token, bytes.NewBufferString("{}"))
+			cltest.CheckStatusCode(t, resp, test.wantRun)
+			resp = cltest.TokenAuthRequest("GET", tokens, token, nil)
+			cltest.CheckStatusCode(t, resp, test.wantTokens)
+		})
+	}
+}
+
+func TestAPITokensController_Destroy(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	token := createAPIToken(t, app, "read-only")
+	specs := app.Server.URL + "/v2/specs"
+	cltest.CheckStatusCode(t, cltest.TokenAuthRequest("GET", specs, token.Token, nil), 200)
+
+	resp := cltest.BasicAuthDelete(app.Server.URL + "/v2/api_tokens/" + token.ID)
+	cltest.CheckStatusCode(t, resp, 200)
+	cltest.CheckStatusCode(t, cltest.TokenAuthRequest("GET", specs, token.Token, nil), 401)
+
+	resp = cltest.BasicAuthDelete(app.Server.URL + "/v2/api_tokens/" + token.ID)
+	cltest.CheckStatusCode(t, resp, 404)
+}
+
+func TestRouter_Unauthenticated(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	resp := cltest.TokenAuthRequest("GET", app.Server.URL+"/v2/specs", "invalid", nil)
+	cltest.CheckStatusCode(t, resp, 401)
+}
@@ -0,0 +1,131 @@
+package web
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/asdine/storm"
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+const (
+	scopeKey = "scope"
+	userKey  = "user"
+)
+
+// authenticate accepts either the operator's username and password as basic
+// auth, which grants every scope, the name and password of a User, which
+// grant its role, or an API token sent as a bearer token.
+func authenticate(store *store.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if scope, user, ok := authenticatedAs(store, c.Request); ok {
+			c.Set(scopeKey, scope)
+			if user != nil {
+				c.Set(userKey, *user)
+			}
+			c.Next()
+			return
+		}
+		c.Header("WWW-Authenticate", `Basic realm="Authorization Required"`)
+		c.AbortWithStatus(http.StatusUnauthorized)
+	}
+}
+
+// authenticatedAs returns the scope granted by the request's credentials,
+// and the User they belong to, which is nil for the node's operator.
+func authenticatedAs(store *store.Store, r *http.Request) (models.Scope, *models.User, bool) {
+	if username, password, ok := r.BasicAuth(); ok {
+		config := store.Config
+		if secureCompare(username, config.BasicAuthUsername) && secureCompare(password, config.BasicAuthPassword) {
+			return models.ScopeAdmin, nil, true
+		}
+		user, err := store.FindUser(username)
+		if err != nil || !user.CheckPassword(password) {
+			return "", nil, false
+		}
+		return user.Role, &user, true
+	}
+
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return "", nil, false
+	}
+	token, err := store.FindAPIToken(strings.TrimPrefix(header, "Bearer "))
+	if err != nil {
+		return "", nil, false
+	} else if token.Owner == "" {
+		return token.Scope, nil, true
+	}
+	user, err := store.FindUser(token.Owner)
+	if err != nil || !user.Role.Permits(token.Scope) {
+		return "", nil, false
+	}
+	return token.Scope, &user, true
+}
+
+// currentUser returns the User making the request, or false if it is made
+// by the node's operator.
+func currentUser(c *gin.Context) (models.User, bool) {
+	if user, ok := c.Get(userKey); ok {
+		return user.(models.User), true
+	}
+	return models.User{}, false
+}
+
+// owns returns true if the request may see a record with the given owner:
+// the operator and admin accounts see everything, other accounts only what
+// they own.
+func owns(c *gin.Context, owner string) bool {
+	user, ok := currentUser(c)
+	return !ok || user.Owns(owner)
+}
+
+// ownerFor returns the owner to record for a new job or bridge: the account
+// named in the request, which must exist, or else the account making it.
+func ownerFor(c *gin.Context, store *store.Store, requested string) (string, error) {
+	if requested == "" {
+		user, _ := currentUser(c)
+		return user.Name, nil
+	} else if _, err := store.FindUser(requested); err == storm.ErrNotFound {
+		return "", fmt.Errorf("Owner %v is not an account", requested)
+	} else if err != nil {
+		return "", err
+	}
+	return requested, nil
+}
+
+// requireUnrestricted rejects requests from accounts that may only see what
+// they own, for endpoints reporting on the whole node.
+func requireUnrestricted() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if user, ok := currentUser(c); ok && user.Restricted() {
+			c.AbortWithStatusJSON(403, gin.H{
+				"errors": []string{"Account role does not permit this request"},
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+func secureCompare(given, actual string) bool {
+	return subtle.ConstantTimeCompare([]byte(given), []byte(actual)) == 1
+}
+
+// requireScope rejects requests whose credentials do not include the
+// required scope.
+func requireScope(required models.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if scope, ok := c.Get(scopeKey); ok && scope.(models.Scope).Permits(required) {
+			c.Next()
+			return
+		}
+		c.AbortWithStatusJSON(403, gin.H{
+			"errors": []string{"Token scope does not permit this request"},
+		})
+	}
+}
@@ -11,7 +11,28 @@ type BridgeTypesController struct {
 	App *services.ChainlinkApplication
 }
 
-// Create adds the BridgeType to the given context.
+// Index lists the BridgeTypes visible to the account.
+// Example:
+//  "<application>/bridge_types"
+func (btc *BridgeTypesController) Index(c *gin.Context) {
+	var bts []models.BridgeType
+	if err := btc.App.GetStore().All(&bts); err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else {
+		visible := []models.BridgeType{}
+		for _, bt := range bts {
+			if owns(c, bt.Owner) {
+				visible = append(visible, bt)
+			}
+		}
+		c.JSON(200, visible)
+	}
+}
+
+// Create adds the BridgeType to the given context, owned by the account
+// given as its owner or else the one creating it.
 func (btc *BridgeTypesController) Create(c *gin.Context) {
 	bt := &models.BridgeType{}
 
@@ -19,6 +40,10 @@ func (btc *BridgeTypesController) Create(c *gin.Context) {
 		c.JSON(500, gin.H{
 			"errors": []string{err.Error()},
 		})
+	} else if bt.Owner, err = ownerFor(c, btc.App.GetStore(), bt.Owner); err != nil {
+		c.JSON(400, gin.H{
+			"errors": []string{err.Error()},
+		})
 	} else if err = btc.App.GetStore().Save(bt); err != nil {
 		c.JSON(500, gin.H{
 			"errors": []string{err.Error()},
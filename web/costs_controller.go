@@ -0,0 +1,26 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// CostsController reports on what fulfilling each job has cost the node.
+type CostsController struct {
+	App *services.ChainlinkApplication
+}
+
+// Index returns the gas used and ETH spent fulfilling runs of every job,
+// the most expensive first.
+// Example:
+//  "<application>/costs"
+func (cc *CostsController) Index(c *gin.Context) {
+	if costs, err := cc.App.Store.RunCosts(); err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else {
+		c.JSON(200, models.SummarizeRunCosts(costs))
+	}
+}
@@ -0,0 +1,47 @@
+package web_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/store/presenters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCostsController_Index(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	j := cltest.NewJobWithWebInitiator()
+	assert.Nil(t, app.Store.SaveJob(&j))
+	for _, gasUsed := range []uint64{30000, 50000} {
+		tx := models.Tx{TxAttempt: models.TxAttempt{
+			Hash:     cltest.NewHash(),
+			GasPrice: big.NewInt(10),
+			GasUsed:  gasUsed,
+		}}
+		cost := models.NewRunCost(j.NewRun(), tx)
+		assert.Nil(t, app.Store.Save(&cost))
+	}
+
+	resp := cltest.BasicAuthGet(app.Server.URL + "/v2/costs")
+	cltest.CheckStatusCode(t, resp, 200)
+	var summaries []models.JobCosts
+	assert.Nil(t, json.Unmarshal(cltest.ParseResponseBody(resp), &summaries))
+	assert.Equal(t, 1, len(summaries))
+	assert.Equal(t, j.ID, summaries[0].JobID)
+	assert.Equal(t, 2, summaries[0].Fulfillments)
+	assert.Equal(t, uint64(80000), summaries[0].GasUsed)
+	assert.Equal(t, big.NewInt(800000), summaries[0].EthSpent)
+	assert.Equal(t, big.NewInt(400000), summaries[0].AverageEthSpent)
+
+	resp = cltest.BasicAuthGet(app.Server.URL + "/v2/specs/" + j.ID)
+	cltest.CheckStatusCode(t, resp, 200)
+	var pj presenters.JobSpec
+	assert.Nil(t, json.Unmarshal(cltest.ParseResponseBody(resp), &pj))
+	assert.Equal(t, summaries[0], *pj.Costs)
+}
@@ -0,0 +1,40 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/services"
+)
+
+// HealthController reports whether the node is able to do its work, for
+// load balancers and monitoring.
+type HealthController struct {
+	App *services.ChainlinkApplication
+}
+
+// Show returns 200 while the node is connected to ETH_URL or still expects
+// to reconnect to it, and 503 otherwise. It does not require credentials,
+// so it never gives the reason, which names ETH_URL.
+// Example:
+//  "<application>/health"
+func (hc *HealthController) Show(c *gin.Context) {
+	if err := hc.App.HeadTracker.Health(); err != nil {
+		c.JSON(503, gin.H{"status": "unhealthy"})
+	} else {
+		c.JSON(200, gin.H{"status": "healthy"})
+	}
+}
+
+// ShowDetails returns the same status as Show, along with the reason the
+// node is unhealthy.
+// Example:
+//  "<application>/v2/health"
+func (hc *HealthController) ShowDetails(c *gin.Context) {
+	if err := hc.App.HeadTracker.Health(); err != nil {
+		c.JSON(503, gin.H{
+			"status": "unhealthy",
+			"errors": []string{err.Error()},
+		})
+	} else {
+		c.JSON(200, gin.H{"status": "healthy"})
+	}
+}
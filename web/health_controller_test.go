@@ -0,0 +1,34 @@
+package web_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthController_Show(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	resp, err := http.Get(app.Server.URL + "/health")
+	assert.Nil(t, err)
+	cltest.CheckStatusCode(t, resp, 200)
+	assert.Contains(t, string(cltest.ParseResponseBody(resp)), `"status":"healthy"`)
+}
+
+func TestHealthController_ShowDetails(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	resp, err := http.Get(app.Server.URL + "/v2/health")
+	assert.Nil(t, err)
+	cltest.CheckStatusCode(t, resp, 401)
+
+	resp = cltest.BasicAuthGet(app.Server.URL + "/v2/health")
+	cltest.CheckStatusCode(t, resp, 200)
+	assert.Contains(t, string(cltest.ParseResponseBody(resp)), `"status":"healthy"`)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/h2non/gock"
 	"github.com/onsi/gomega"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/services"
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/smartcontractkit/chainlink/utils"
@@ -149,6 +150,39 @@ func TestIntegration_EthLog(t *testing.T) {
 	cltest.WaitForRuns(t, j, app.Store, 1)
 }
 
+func TestIntegration_EthLog_RecordsRejectedRuns(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	eth := app.MockEthClient()
+	logs := make(chan types.Log, 2)
+	eth.RegisterSubscription("logs", logs)
+	app.Start()
+
+	j := cltest.NewJobWithLogInitiator()
+	j.Initiators[0].Address = common.HexToAddress("0x3cCad4715152693fE3BC4460591e3D3Fbd071b42")
+	j.StartAt = cltest.NullableTime(cltest.ParseISO8601("3000-01-01T00:00:00.000Z"))
+	assert.Nil(t, app.AddJob(j))
+
+	logs <- cltest.LogFromFixture("../internal/fixtures/eth/subscription_logs_hello_world.json")
+	logs <- cltest.LogFromFixture("../internal/fixtures/eth/subscription_logs_hello_world.json")
+	gomega.NewGomegaWithT(t).Eventually(func() uint {
+		errs, err := app.Store.JobSpecErrorsFor(j.ID)
+		assert.Nil(t, err)
+		if len(errs) == 0 {
+			return 0
+		}
+		return errs[0].Occurrences
+	}).Should(gomega.Equal(uint(2)))
+
+	errs, err := app.Store.JobSpecErrorsFor(j.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "Rejected run for a log: job has not started", errs[0].Description)
+	cltest.WaitForRuns(t, j, app.Store, 0)
+}
+
 func TestIntegration_RunLog(t *testing.T) {
 	t.Parallel()
 	app, cleanup := cltest.NewApplication()
@@ -157,6 +191,9 @@ func TestIntegration_RunLog(t *testing.T) {
 	eth := app.MockEthClient()
 	logs := make(chan types.Log, 1)
 	eth.RegisterSubscription("logs", logs)
+	requester := cltest.NewAddress()
+	txHash := common.HexToHash("0xb7862c896a6ba2711bccc0410184e46d793ea83b3e05470f1d359ea276d16bb5")
+	eth.Register("eth_getTransactionByHash", store.Transaction{Hash: txHash, From: requester})
 	app.Start()
 
 	gock.EnableNetworking()
@@ -172,9 +209,15 @@ func TestIntegration_RunLog(t *testing.T) {
 	app.Store.One("JobID", j.ID, &initr)
 	assert.Equal(t, models.InitiatorRunLog, initr.Type)
 
-	logs <- cltest.NewRunLog(j.ID, cltest.NewAddress(), `{"url":"https://etherprice.com/api"}`)
+	log := cltest.NewRunLog(j.ID, cltest.NewAddress(), `{"url":"https://etherprice.com/api"}`)
+	log.TxHash = txHash
+	logs <- log
 
-	cltest.WaitForRuns(t, j, app.Store, 1)
+	jr := cltest.WaitForRuns(t, j, app.Store, 1)[0]
+	assert.Equal(t, txHash, jr.TxHash)
+	assert.Equal(t, log.Topics[services.EventTopicRequestID], jr.RequestID)
+	assert.Equal(t, requester, jr.Requester)
+	eth.EnsureAllCalled(t)
 }
 
 func TestIntegration_EndAt(t *testing.T) {
@@ -265,7 +308,7 @@ func TestIntegration_ExternalAdapter_Pending(t *testing.T) {
 		func(body string) {
 			jrs := cltest.WaitForRuns(t, j, app.Store, 1)
 			jr := jrs[0]
-			assert.JSONEq(t, fmt.Sprintf(`{"id":"%v","data":{}}`, jr.ID), body)
+			assert.JSONEq(t, fmt.Sprintf(`{"id":"%v","idempotencyKey":"%v","data":{}}`, jr.ID, jr.TaskRuns[0].ID), body)
 		})
 	defer cleanup()
 
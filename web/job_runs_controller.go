@@ -1,11 +1,16 @@
 package web
 
 import (
+	"errors"
+	"fmt"
 	"io/ioutil"
+	"math/big"
+	"strings"
 
 	"github.com/asdine/storm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gin-gonic/gin"
-	"github.com/smartcontractkit/chainlink/logger"
 	"github.com/smartcontractkit/chainlink/services"
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
@@ -22,7 +27,15 @@ type JobRunsController struct {
 func (jrc *JobRunsController) Index(c *gin.Context) {
 	id := c.Param("SpecID")
 
-	if jobRuns, err := jrc.App.Store.JobRunsFor(id); err != nil {
+	if visible, err := jrc.ownsJob(c, id); err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else if !visible {
+		c.JSON(404, gin.H{
+			"errors": []string{"Job not found"},
+		})
+	} else if jobRuns, err := jrc.App.Store.JobRunsFor(id); err != nil {
 		c.JSON(500, gin.H{
 			"errors": []string{err.Error()},
 		})
@@ -37,7 +50,7 @@ func (jrc *JobRunsController) Index(c *gin.Context) {
 func (jrc *JobRunsController) Create(c *gin.Context) {
 	id := c.Param("SpecID")
 
-	if j, err := jrc.App.Store.FindJob(id); err == storm.ErrNotFound {
+	if j, err := jrc.App.Store.FindJob(id); err == storm.ErrNotFound || (err == nil && !owns(c, j.Owner)) {
 		c.JSON(404, gin.H{
 			"errors": []string{"Job not found"},
 		})
@@ -71,7 +84,9 @@ func getRunData(c *gin.Context) (models.JSON, error) {
 }
 
 // Update allows external adapters to resume a JobRun, reporting the result of
-// the task and marking it no longer pending.
+// the task and marking it no longer pending. If the result includes the
+// idempotency key sent with the bridge request, it must be the pending
+// task's.
 // Example:
 //  "<application>/runs/:RunID"
 func (jrc *JobRunsController) Update(c *gin.Context) {
@@ -85,6 +100,14 @@ func (jrc *JobRunsController) Update(c *gin.Context) {
 		c.JSON(500, gin.H{
 			"errors": []string{err.Error()},
 		})
+	} else if visible, err := jrc.ownsJob(c, jr.JobID); err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else if !visible {
+		c.JSON(404, gin.H{
+			"errors": []string{"Job Run not found"},
+		})
 	} else if !jr.Result.Pending {
 		c.JSON(405, gin.H{
 			"errors": []string{"Cannot resume a job run that isn't pending"},
@@ -93,12 +116,142 @@ func (jrc *JobRunsController) Update(c *gin.Context) {
 		c.JSON(500, gin.H{
 			"errors": []string{err.Error()},
 		})
+	} else if rr.IdempotencyKey != "" && rr.IdempotencyKey != jr.NextTaskRun().ID {
+		c.JSON(409, gin.H{
+			"errors": []string{"Idempotency key does not match the pending task"},
+		})
 	} else {
 		executeRun(jr, jrc.App.Store, rr)
 		c.JSON(200, gin.H{"id": jr.ID})
 	}
 }
 
+// Search lists the Runs initiated by logs with the given transaction hash,
+// request ID or requester address, matching all that are given, among the
+// jobs visible to the account.
+// Example:
+//  "<application>/runs?txHash=0x...&requestId=0x...&requester=0x..."
+func (jrc *JobRunsController) Search(c *gin.Context) {
+	if jrq, err := parseJobRunQuery(c); err != nil {
+		code := 400
+		if _, ok := err.(invalidQueryError); ok {
+			code = 422
+		}
+		c.JSON(code, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else if jobRuns, err := jrc.App.Store.SearchJobRuns(jrq); err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else if visible, err := jrc.ownedRuns(c, jobRuns); err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else {
+		c.JSON(200, gin.H{"runs": visible})
+	}
+}
+
+// ownsJob returns true if the account may see the job with the given ID.
+// Accounts that see everything are not held up by a lookup.
+func (jrc *JobRunsController) ownsJob(c *gin.Context, jobID string) (bool, error) {
+	if user, ok := currentUser(c); !ok || !user.Restricted() {
+		return true, nil
+	}
+	job, err := jrc.App.Store.FindJob(jobID)
+	if err == storm.ErrNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return owns(c, job.Owner), nil
+}
+
+// ownedRuns returns the runs whose jobs the account may see.
+func (jrc *JobRunsController) ownedRuns(c *gin.Context, runs []models.JobRun) ([]models.JobRun, error) {
+	visible := []models.JobRun{}
+	jobs := map[string]bool{}
+	for _, jr := range runs {
+		ok, cached := jobs[jr.JobID]
+		if !cached {
+			var err error
+			if ok, err = jrc.ownsJob(c, jr.JobID); err != nil {
+				return visible, err
+			}
+			jobs[jr.JobID] = ok
+		}
+		if ok {
+			visible = append(visible, jr)
+		}
+	}
+	return visible, nil
+}
+
+// invalidQueryError is returned for a search parameter that is given but
+// cannot be parsed.
+type invalidQueryError struct {
+	msg string
+}
+
+func (e invalidQueryError) Error() string {
+	return e.msg
+}
+
+func parseJobRunQuery(c *gin.Context) (models.JobRunQuery, error) {
+	jrq := models.JobRunQuery{}
+	var err error
+	if s := c.Query("txHash"); s != "" {
+		if jrq.TxHash, err = parseHash(s); err != nil {
+			return jrq, invalidQueryError{fmt.Sprintf("Invalid txHash: %v", err)}
+		}
+	}
+	if s := c.Query("requestId"); s != "" {
+		if jrq.RequestID, err = parseRequestID(s); err != nil {
+			return jrq, invalidQueryError{fmt.Sprintf("Invalid requestId: %v", err)}
+		}
+	}
+	if s := c.Query("requester"); s != "" {
+		if !common.IsHexAddress(s) {
+			return jrq, invalidQueryError{fmt.Sprintf("Invalid requester: %v is not an address", s)}
+		}
+		jrq.Requester = common.HexToAddress(s)
+	}
+	if jrq.Empty() {
+		return jrq, errors.New("Must search by txHash, requestId or requester")
+	}
+	return jrq, nil
+}
+
+func parseHash(s string) (common.Hash, error) {
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return common.Hash{}, err
+	} else if len(b) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("%v is not %v bytes", s, common.HashLength)
+	}
+	return common.BytesToHash(b), nil
+}
+
+// parseRequestID accepts the request ID as either the hex encoded topic or
+// its decimal value.
+func parseRequestID(s string) (common.Hash, error) {
+	if strings.HasPrefix(s, "0x") {
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return common.Hash{}, err
+		} else if len(b) > common.HashLength {
+			return common.Hash{}, fmt.Errorf("%v is longer than %v bytes", s, common.HashLength)
+		}
+		return common.BytesToHash(b), nil
+	}
+	id, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return common.Hash{}, fmt.Errorf("%v is not a number", s)
+	}
+	return common.BigToHash(id), nil
+}
+
 func startJob(j models.JobSpec, s *store.Store, body models.JSON) (models.JobRun, error) {
 	jr, err := services.BuildRun(j, s)
 	if err != nil {
@@ -109,9 +262,5 @@ func startJob(j models.JobSpec, s *store.Store, body models.JSON) (models.JobRun
 }
 
 func executeRun(jr models.JobRun, s *store.Store, rr models.RunResult) {
-	go func() {
-		if _, err := services.ExecuteRun(jr, s, rr); err != nil {
-			logger.Error("Web initiator: ", err.Error())
-		}
-	}()
+	go services.EnqueueRun(jr, s, rr)
 }
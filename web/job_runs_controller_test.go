@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math/big"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
 	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/stretchr/testify/assert"
@@ -45,6 +48,58 @@ func TestJobRunsController_Index(t *testing.T) {
 	assert.Equal(t, jr1.ID, respJSON.Runs[1].ID, "expected runs ordered by created at(descending)")
 }
 
+func TestJobRunsController_Search(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	requester := cltest.NewAddress()
+	j := cltest.NewJob()
+	assert.Nil(t, app.Store.SaveJob(&j))
+	jr := j.NewRun()
+	jr.TxHash = common.HexToHash("0xb7862c896a6ba2711bccc0410184e46d793ea83b3e05470f1d359ea276d16bb5")
+	jr.RequestID = common.BigToHash(big.NewInt(42))
+	jr.Requester = requester
+	assert.Nil(t, app.Store.Save(&jr))
+	other := j.NewRun()
+	assert.Nil(t, app.Store.Save(&other))
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantRuns   int
+	}{
+		{"tx hash", "txHash=" + jr.TxHash.Hex(), 200, 1},
+		{"hex request ID", "requestId=" + jr.RequestID.Hex(), 200, 1},
+		{"decimal request ID", "requestId=42", 200, 1},
+		{"requester", "requester=" + requester.Hex(), 200, 1},
+		{"unknown requester", "requester=" + cltest.NewAddress().Hex(), 200, 0},
+		{"invalid tx hash", "txHash=0x1234", 422, 0},
+		{"invalid hex request ID", "requestId=0xnothex", 422, 0},
+		{"too long request ID", "requestId=0x" + strings.Repeat("ab", 33), 422, 0},
+		{"invalid decimal request ID", "requestId=forty", 422, 0},
+		{"invalid requester", "requester=bob", 422, 0},
+		{"no parameters", "", 400, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := cltest.BasicAuthGet(app.Server.URL + "/v2/runs?" + test.query)
+			cltest.CheckStatusCode(t, resp, test.wantStatus)
+			if test.wantStatus != 200 {
+				return
+			}
+			var respJSON JobRunsJSON
+			assert.Nil(t, json.Unmarshal(cltest.ParseResponseBody(resp), &respJSON))
+			assert.Equal(t, test.wantRuns, len(respJSON.Runs))
+			if test.wantRuns > 0 {
+				assert.Equal(t, jr.ID, respJSON.Runs[0].ID)
+			}
+		})
+	}
+}
+
 func TestJobRunsController_Create_Success(t *testing.T) {
 	t.Parallel()
 	app, cleanup := cltest.NewApplication()
@@ -135,6 +190,33 @@ func TestJobRunsController_Update_Success(t *testing.T) {
 	assert.Equal(t, "100", val)
 }
 
+func TestJobRunsController_Update_IdempotencyKey(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	bt := cltest.NewBridgeType()
+	assert.Nil(t, app.Store.Save(&bt))
+	j := cltest.NewJob()
+	j.Tasks = []models.TaskSpec{cltest.NewTask(bt.Name)}
+	assert.Nil(t, app.Store.Save(&j))
+	jr := cltest.MarkJobRunPending(j.NewRun(), 0)
+	assert.Nil(t, app.Store.Save(&jr))
+
+	url := app.Server.URL + "/v2/runs/" + jr.ID
+	body := fmt.Sprintf(`{"id":"%v","idempotencyKey":"%v","data":{"value": "100"}}`, jr.ID, "other")
+	resp := cltest.BasicAuthPatch(url, "application/json", bytes.NewBufferString(body))
+	assert.Equal(t, 409, resp.StatusCode, "Response should be a conflict")
+
+	body = fmt.Sprintf(`{"id":"%v","idempotencyKey":"%v","data":{"value": "100"}}`, jr.ID, jr.TaskRuns[0].ID)
+	resp = cltest.BasicAuthPatch(url, "application/json", bytes.NewBufferString(body))
+	assert.Equal(t, 200, resp.StatusCode, "Response should be successful")
+	jr = cltest.WaitForJobRunToComplete(t, app.Store, jr)
+	val, err := jr.Result.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "100", val)
+}
+
 func TestJobRunsController_Update_NotPending(t *testing.T) {
 	t.Parallel()
 	app, cleanup := cltest.NewApplication()
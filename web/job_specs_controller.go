@@ -13,7 +13,7 @@ type JobSpecsController struct {
 	App *services.ChainlinkApplication
 }
 
-// Index lists all of the existing JobSpecs.
+// Index lists all of the existing JobSpecs visible to the account.
 // Example:
 //  "<application>/specs"
 func (jsc *JobSpecsController) Index(c *gin.Context) {
@@ -23,15 +23,18 @@ func (jsc *JobSpecsController) Index(c *gin.Context) {
 			"errors": []string{err.Error()},
 		})
 	} else {
-		pjs := make([]presenters.JobSpec, len(jobs))
-		for i, j := range jobs {
-			pjs[i] = presenters.JobSpec{JobSpec: j}
+		pjs := []presenters.JobSpec{}
+		for _, j := range jobs {
+			if owns(c, j.Owner) {
+				pjs = append(pjs, presenters.JobSpec{JobSpec: j})
+			}
 		}
 		c.JSON(200, pjs)
 	}
 }
 
-// Create adds validates, saves, and starts a new JobSpec.
+// Create adds validates, saves, and starts a new JobSpec, owned by the
+// account given as its owner or else the one creating it.
 // Example:
 //  "<application>/specs"
 func (jsc *JobSpecsController) Create(c *gin.Context) {
@@ -45,6 +48,10 @@ func (jsc *JobSpecsController) Create(c *gin.Context) {
 		c.JSON(400, gin.H{
 			"errors": []string{err.Error()},
 		})
+	} else if j.Owner, err = ownerFor(c, jsc.App.Store, j.Owner); err != nil {
+		c.JSON(400, gin.H{
+			"errors": []string{err.Error()},
+		})
 	} else if err = jsc.App.AddJob(j); err != nil {
 		c.JSON(500, gin.H{
 			"errors": []string{err.Error()},
@@ -59,7 +66,7 @@ func (jsc *JobSpecsController) Create(c *gin.Context) {
 //  "<application>/specs/:SpecID"
 func (jsc *JobSpecsController) Show(c *gin.Context) {
 	id := c.Param("SpecID")
-	if j, err := jsc.App.Store.FindJob(id); err == storm.ErrNotFound {
+	if j, err := jsc.App.Store.FindJob(id); err == storm.ErrNotFound || (err == nil && !owns(c, j.Owner)) {
 		c.JSON(404, gin.H{
 			"errors": []string{"JobSpec not found."},
 		})
@@ -71,7 +78,49 @@ func (jsc *JobSpecsController) Show(c *gin.Context) {
 		c.JSON(500, gin.H{
 			"errors": []string{err.Error()},
 		})
+	} else if errs, err := jsc.App.Store.JobSpecErrorsFor(j.ID); err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else if costs, err := jsc.App.Store.RunCostsFor(j.ID); err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else {
+		pj := presenters.JobSpec{JobSpec: j, Runs: runs, Errors: errs}
+		if summaries := models.SummarizeRunCosts(costs); len(summaries) > 0 {
+			pj.Costs = &summaries[0]
+		}
+		c.JSON(200, pj)
+	}
+}
+
+// DestroyError dismisses an error recorded for a JobSpec.
+// Example:
+//  "<application>/specs/:SpecID/errors/:ErrorID"
+func (jsc *JobSpecsController) DestroyError(c *gin.Context) {
+	var jse models.JobSpecError
+	if err := jsc.App.Store.One("ID", c.Param("ErrorID"), &jse); err == storm.ErrNotFound || (err == nil && jse.JobID != c.Param("SpecID")) {
+		c.JSON(404, gin.H{
+			"errors": []string{"JobSpec error not found."},
+		})
+	} else if err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else if j, err := jsc.App.Store.FindJob(jse.JobID); err != nil && err != storm.ErrNotFound {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else if err == nil && !owns(c, j.Owner) {
+		c.JSON(404, gin.H{
+			"errors": []string{"JobSpec error not found."},
+		})
+	} else if err := jsc.App.Store.DeleteStruct(&jse); err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
 	} else {
-		c.JSON(200, presenters.JobSpec{j, runs})
+		c.JSON(200, gin.H{"id": jse.ID})
 	}
 }
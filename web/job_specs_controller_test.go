@@ -167,6 +167,36 @@ func TestJobSpecsController_Show(t *testing.T) {
 	assert.Equal(t, respJob.Runs[1].ID, jr1.ID, "should have job runs ordered by created at(descending)")
 }
 
+func TestJobSpecsController_ShowAndDestroyError(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	j := cltest.NewJobWithWebInitiator()
+	assert.Nil(t, app.Store.SaveJob(&j))
+	jse, err := app.Store.RecordJobSpecError(j.ID, "Error in log subscription: boom")
+	assert.Nil(t, err)
+	_, err = app.Store.RecordJobSpecError(j.ID, "Error in log subscription: boom")
+	assert.Nil(t, err)
+
+	resp := cltest.BasicAuthGet(app.Server.URL + "/v2/specs/" + j.ID)
+	cltest.CheckStatusCode(t, resp, 200)
+	var respJob presenters.JobSpec
+	assert.Nil(t, json.Unmarshal(cltest.ParseResponseBody(resp), &respJob))
+	assert.Equal(t, 1, len(respJob.Errors))
+	assert.Equal(t, jse.ID, respJob.Errors[0].ID)
+	assert.Equal(t, uint(2), respJob.Errors[0].Occurrences)
+
+	resp = cltest.BasicAuthDelete(app.Server.URL + "/v2/specs/other/errors/" + jse.ID)
+	cltest.CheckStatusCode(t, resp, 404)
+	resp = cltest.BasicAuthDelete(app.Server.URL + "/v2/specs/" + j.ID + "/errors/" + jse.ID)
+	cltest.CheckStatusCode(t, resp, 200)
+
+	errs, err := app.Store.JobSpecErrorsFor(j.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(errs))
+}
+
 func TestJobSpecsController_Show_NotFound(t *testing.T) {
 	t.Parallel()
 	app, cleanup := cltest.NewApplication()
@@ -185,3 +215,39 @@ func TestJobSpecsController_Show_Unauthenticated(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 401, resp.StatusCode, "Response should be forbidden")
 }
+
+func TestJobSpecsController_Create_Owner(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	user, err := models.NewUser("analyst", "secret", models.ScopeReadOnly)
+	assert.Nil(t, err)
+	assert.Nil(t, app.Store.Save(&user))
+
+	tests := []struct {
+		name      string
+		owner     string
+		wantCode  int
+		wantOwner string
+	}{
+		{"operator", "", 200, ""},
+		{"assigned", "analyst", 200, "analyst"},
+		{"unknown account", "nobody", 400, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body := `{"owner":"` + test.owner + `","initiators":[{"type":"web"}],"tasks":[{"type":"NoOp"}]}`
+			resp := cltest.BasicAuthPost(app.Server.URL+"/v2/specs", "application/json", bytes.NewBufferString(body))
+			cltest.CheckStatusCode(t, resp, test.wantCode)
+			if test.wantCode != 200 {
+				return
+			}
+			var created struct{ ID string }
+			assert.Nil(t, json.Unmarshal(cltest.ParseResponseBody(resp), &created))
+			j, err := app.Store.FindJob(created.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantOwner, j.Owner)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package web
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// MetricsController exposes the node's adapter metrics for scraping.
+type MetricsController struct {
+	App *services.ChainlinkApplication
+}
+
+// Show returns the executions, failures and durations of every adapter
+// type per job, and the latency and gap of recent heads, in the Prometheus
+// text format.
+// Example:
+//  "<application>/metrics"
+func (mc *MetricsController) Show(c *gin.Context) {
+	samples, err := mc.App.Store.HeadSamples()
+	if err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+		return
+	}
+	entries := mc.App.Store.Metrics.ByJob()
+	var buf bytes.Buffer
+	series := []struct {
+		name, kind, help string
+		value            func(i int) float64
+	}{
+		{"chainlink_adapter_executions_total", "counter", "Number of times an adapter has performed.",
+			func(i int) float64 { return float64(entries[i].Count) }},
+		{"chainlink_adapter_errors_total", "counter", "Number of times an adapter has returned an error.",
+			func(i int) float64 { return float64(entries[i].Errors) }},
+		{"chainlink_adapter_duration_seconds_total", "counter", "Time spent performing an adapter.",
+			func(i int) float64 { return entries[i].TotalDuration.Seconds() }},
+		{"chainlink_adapter_duration_seconds_max", "gauge", "Longest time taken by a single execution of an adapter.",
+			func(i int) float64 { return entries[i].MaxDuration.Seconds() }},
+	}
+	for _, s := range series {
+		fmt.Fprintf(&buf, "# HELP %s %s\n# TYPE %s %s\n", s.name, s.help, s.name, s.kind)
+		for i, entry := range entries {
+			fmt.Fprintf(&buf, "%s{adapter=%q,job_id=%q} %v\n", s.name, entry.Adapter, entry.JobID, s.value(i))
+		}
+	}
+	timings := models.SummarizeHeadSamples(samples)
+	heads := []struct {
+		name, help string
+		value      models.Duration
+	}{
+		{"chainlink_head_latency_seconds_avg", "Average time between a block's timestamp and receiving its head.", timings.AverageLatency},
+		{"chainlink_head_latency_seconds_max", "Longest time between a block's timestamp and receiving its head.", timings.MaxLatency},
+		{"chainlink_head_gap_seconds_avg", "Average time between consecutive blocks.", timings.AverageGap},
+		{"chainlink_head_gap_seconds_max", "Longest time between consecutive blocks.", timings.MaxGap},
+	}
+	for _, h := range heads {
+		fmt.Fprintf(&buf, "# HELP %s %s\n# TYPE %s gauge\n%s %v\n", h.name, h.help, h.name, h.name, h.value.Seconds())
+	}
+	c.Data(200, "text/plain; version=0.0.4", buf.Bytes())
+}
@@ -0,0 +1,39 @@
+package web_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsController_Show(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	app.Store.Metrics.Record("httpget", "abc", 1500*time.Millisecond, nil)
+	app.Store.Metrics.Record("httpget", "abc", 500*time.Millisecond, errors.New("timeout"))
+	mined := time.Unix(1500000000, 0)
+	for i, latency := range []time.Duration{time.Second, 3 * time.Second} {
+		blockTime := mined.Add(time.Duration(i) * 15 * time.Second)
+		sample := models.HeadSample{Number: uint64(i + 1), BlockTime: blockTime, ReceivedAt: blockTime.Add(latency)}
+		assert.Nil(t, app.Store.SaveHeadSample(&sample, 10))
+	}
+
+	resp := cltest.BasicAuthGet(app.Server.URL + "/v2/metrics")
+	cltest.CheckStatusCode(t, resp, 200)
+	body := string(cltest.ParseResponseBody(resp))
+	assert.Contains(t, body, "# TYPE chainlink_adapter_executions_total counter\n")
+	assert.Contains(t, body, `chainlink_adapter_executions_total{adapter="httpget",job_id="abc"} 2`)
+	assert.Contains(t, body, `chainlink_adapter_errors_total{adapter="httpget",job_id="abc"} 1`)
+	assert.Contains(t, body, `chainlink_adapter_duration_seconds_total{adapter="httpget",job_id="abc"} 2`)
+	assert.Contains(t, body, `chainlink_adapter_duration_seconds_max{adapter="httpget",job_id="abc"} 1.5`)
+	assert.Contains(t, body, "# TYPE chainlink_head_latency_seconds_avg gauge\nchainlink_head_latency_seconds_avg 2\n")
+	assert.Contains(t, body, "chainlink_head_latency_seconds_max 3\n")
+	assert.Contains(t, body, "chainlink_head_gap_seconds_avg 15\n")
+	assert.Contains(t, body, "chainlink_head_gap_seconds_max 15\n")
+}
@@ -10,29 +10,55 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/smartcontractkit/chainlink/logger"
 	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/models"
 )
 
 // Router listens and responds to requests to the node for valid paths.
 func Router(app *services.ChainlinkApplication) *gin.Engine {
 	engine := gin.New()
-	config := app.Store.Config
-	basicAuth := gin.BasicAuth(gin.Accounts{config.BasicAuthUsername: config.BasicAuthPassword})
-	engine.Use(loggerFunc(), gin.Recovery(), basicAuth)
+	engine.Use(loggerFunc(), gin.Recovery())
+	// health is checked before authenticating, so it needs no credentials
+	hc := HealthController{app}
+	engine.GET("/health", hc.Show)
+	engine.Use(authenticate(app.Store))
 
 	v2 := engine.Group("/v2")
 	{
 		j := JobSpecsController{app}
-		v2.GET("/specs", j.Index)
-		v2.POST("/specs", j.Create)
-		v2.GET("/specs/:SpecID", j.Show)
-
 		jr := JobRunsController{app}
-		v2.GET("/specs/:SpecID/runs", jr.Index)
-		v2.POST("/specs/:SpecID/runs", jr.Create)
-		v2.PATCH("/runs/:RunID", jr.Update)
-
 		tt := BridgeTypesController{app}
-		v2.POST("/bridge_types", tt.Create)
+		read := v2.Group("", requireScope(models.ScopeReadOnly))
+		read.GET("/specs", j.Index)
+		read.GET("/specs/:SpecID", j.Show)
+		read.GET("/specs/:SpecID/runs", jr.Index)
+		read.GET("/runs", jr.Search)
+		read.GET("/bridge_types", tt.Index)
+
+		sc := StatsController{app}
+		mc := MetricsController{app}
+		cc := CostsController{app}
+		node := read.Group("", requireUnrestricted())
+		node.GET("/store/stats", sc.Show)
+		node.GET("/metrics", mc.Show)
+		node.GET("/costs", cc.Index)
+		node.GET("/health", hc.ShowDetails)
+
+		run := v2.Group("", requireScope(models.ScopeRunTrigger))
+		run.POST("/specs/:SpecID/runs", jr.Create)
+		run.PATCH("/runs/:RunID", jr.Update)
+
+		at := APITokensController{app}
+		uc := UsersController{app}
+		admin := v2.Group("", requireScope(models.ScopeAdmin))
+		admin.POST("/specs", j.Create)
+		admin.DELETE("/specs/:SpecID/errors/:ErrorID", j.DestroyError)
+		admin.POST("/bridge_types", tt.Create)
+		admin.GET("/api_tokens", at.Index)
+		admin.POST("/api_tokens", at.Create)
+		admin.DELETE("/api_tokens/:TokenID", at.Destroy)
+		admin.GET("/users", uc.Index)
+		admin.POST("/users", uc.Create)
+		admin.DELETE("/users/:Name", uc.Destroy)
 	}
 
 	return engine
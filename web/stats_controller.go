@@ -0,0 +1,29 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/presenters"
+)
+
+// StatsController reports on the state of the node's database and the
+// performance of its adapters.
+type StatsController struct {
+	App *services.ChainlinkApplication
+}
+
+// Show returns the record counts, file size and free page ratio of the
+// database, the age of the oldest pending run, and the number, duration
+// and failures of adapter executions.
+// Example:
+//  "<application>/store/stats"
+func (sc *StatsController) Show(c *gin.Context) {
+	store := sc.App.Store
+	if stats, err := store.Stats(); err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else {
+		c.JSON(200, presenters.NewStoreStats(stats, store.Metrics, store.Clock.Now()))
+	}
+}
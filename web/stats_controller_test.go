@@ -0,0 +1,48 @@
+package web_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/store/presenters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsController_Show(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	j := cltest.NewJob()
+	assert.Nil(t, app.Store.SaveJob(&j))
+	jr := j.NewRun()
+	jr.Status = models.StatusPending
+	jr.CreatedAt = time.Now().Add(-time.Hour)
+	assert.Nil(t, app.Store.Save(&jr))
+	app.Store.Metrics.Record("httpget", j.ID, 2*time.Second, nil)
+	app.Store.Metrics.Record("httpget", j.ID, 4*time.Second, errors.New("timeout"))
+
+	resp := cltest.BasicAuthGet(app.Server.URL + "/v2/store/stats")
+	cltest.CheckStatusCode(t, resp, 200)
+
+	var stats presenters.StoreStats
+	assert.Nil(t, json.Unmarshal(cltest.ParseResponseBody(resp), &stats))
+	assert.Equal(t, 1, stats.Counts["JobSpec"])
+	assert.Equal(t, 1, stats.Counts["JobRun"])
+	assert.True(t, stats.FileSize > 0)
+	assert.NotEmpty(t, stats.OldestPendingRunAge)
+	assert.True(t, jr.CreatedAt.Equal(*stats.OldestPendingRunCreatedAt))
+
+	assert.Equal(t, 1, len(stats.Adapters))
+	assert.Equal(t, "httpget", stats.Adapters[0].Adapter)
+	assert.Equal(t, uint64(2), stats.Adapters[0].Count)
+	assert.Equal(t, uint64(1), stats.Adapters[0].Errors)
+	assert.Equal(t, 3*time.Second, stats.Adapters[0].AverageDuration.Duration)
+	assert.Equal(t, 1, len(stats.AdaptersByJob))
+	assert.Equal(t, j.ID, stats.AdaptersByJob[0].JobID)
+	assert.Equal(t, "timeout", stats.AdaptersByJob[0].LastError)
+}
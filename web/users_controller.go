@@ -0,0 +1,85 @@
+package web
+
+import (
+	"github.com/asdine/storm"
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// UsersController manages the operator accounts of the node.
+type UsersController struct {
+	App *services.ChainlinkApplication
+}
+
+// UserRequest holds the parameters for creating a User.
+type UserRequest struct {
+	Name     string       `json:"name" binding:"required"`
+	Password string       `json:"password" binding:"required"`
+	Role     models.Scope `json:"role" binding:"required"`
+}
+
+// Index lists all of the Users, without their passwords.
+// Example:
+//  "<application>/users"
+func (uc *UsersController) Index(c *gin.Context) {
+	if users, err := uc.App.Store.Users(); err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else {
+		c.JSON(200, gin.H{"users": users})
+	}
+}
+
+// Create adds a User with the requested name, password and role.
+// Example:
+//  "<application>/users"
+func (uc *UsersController) Create(c *gin.Context) {
+	req := UserRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else if _, err := uc.App.Store.FindUser(req.Name); err == nil {
+		c.JSON(409, gin.H{
+			"errors": []string{"User already exists"},
+		})
+	} else if err != storm.ErrNotFound {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else if user, err := models.NewUser(req.Name, req.Password, req.Role); err != nil {
+		c.JSON(400, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else if err := uc.App.Store.Save(&user); err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else {
+		c.JSON(200, user)
+	}
+}
+
+// Destroy removes the User with the given name and revokes its API tokens.
+// The jobs and bridges it owned are kept, visible only to admins.
+// Example:
+//  "<application>/users/:Name"
+func (uc *UsersController) Destroy(c *gin.Context) {
+	if user, err := uc.App.Store.FindUser(c.Param("Name")); err == storm.ErrNotFound {
+		c.JSON(404, gin.H{
+			"errors": []string{"User not found"},
+		})
+	} else if err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else if err := uc.App.Store.DeleteUser(user); err != nil {
+		c.JSON(500, gin.H{
+			"errors": []string{err.Error()},
+		})
+	} else {
+		c.JSON(200, gin.H{"name": user.Name})
+	}
+}
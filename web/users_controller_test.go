@@ -0,0 +1,147 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func createUser(t *testing.T, app *cltest.TestApplication, name, role string) {
+	resp := cltest.BasicAuthPost(
+		app.Server.URL+"/v2/users",
+		"application/json",
+		bytes.NewBufferString(`{"name":"`+name+`","password":"secret","role":"`+role+`"}`),
+	)
+	cltest.CheckStatusCode(t, resp, 200)
+}
+
+func TestUsersController_Create(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	createUser(t, app, "analyst", "read-only")
+	user, err := app.Store.FindUser("analyst")
+	assert.Nil(t, err)
+	assert.Equal(t, models.ScopeReadOnly, user.Role)
+	assert.True(t, user.CheckPassword("secret"))
+	assert.False(t, user.CheckPassword("twochains"))
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"existing name", `{"name":"analyst","password":"secret","role":"admin"}`, 409},
+		{"unknown role", `{"name":"ops","password":"secret","role":"superuser"}`, 400},
+		{"missing password", `{"name":"ops","role":"admin"}`, 400},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := cltest.BasicAuthPost(
+				app.Server.URL+"/v2/users",
+				"application/json",
+				bytes.NewBufferString(test.body),
+			)
+			cltest.CheckStatusCode(t, resp, test.want)
+		})
+	}
+}
+
+func TestUsersController_Roles(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	createUser(t, app, "analyst", "read-only")
+	createUser(t, app, "ops", "admin")
+
+	owned := cltest.NewJobWithWebInitiator()
+	owned.Owner = "analyst"
+	assert.Nil(t, app.Store.SaveJob(&owned))
+	other := cltest.NewJobWithWebInitiator()
+	assert.Nil(t, app.Store.SaveJob(&other))
+
+	specs := app.Server.URL + "/v2/specs"
+	tests := []struct {
+		name      string
+		wantSpecs int
+		wantOther int
+		wantRun   int
+		wantStats int
+		wantUsers int
+	}{
+		{"analyst", 1, 404, 403, 403, 403},
+		{"ops", 2, 200, 200, 200, 200},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp, err := utils.BasicAuthGet(test.name, "secret", specs)
+			assert.Nil(t, err)
+			cltest.CheckStatusCode(t, resp, 200)
+			var jobs []models.JobSpec
+			assert.Nil(t, json.Unmarshal(cltest.ParseResponseBody(resp), &jobs))
+			assert.Equal(t, test.wantSpecs, len(jobs))
+
+			resp, err = utils.BasicAuthGet(test.name, "secret", specs+"/"+other.ID)
+			assert.Nil(t, err)
+			cltest.CheckStatusCode(t, resp, test.wantOther)
+
+			resp, err = utils.BasicAuthPost(test.name, "secret", specs+"/"+owned.ID+"/runs", "application/json", bytes.NewBufferString("{}"))
+			assert.Nil(t, err)
+			cltest.CheckStatusCode(t, resp, test.wantRun)
+
+			resp, err = utils.BasicAuthGet(test.name, "secret", app.Server.URL+"/v2/store/stats")
+			assert.Nil(t, err)
+			cltest.CheckStatusCode(t, resp, test.wantStats)
+
+			resp, err = utils.BasicAuthGet(test.name, "secret", app.Server.URL+"/v2/users")
+			assert.Nil(t, err)
+			cltest.CheckStatusCode(t, resp, test.wantUsers)
+		})
+	}
+
+	resp, err := utils.BasicAuthGet("analyst", "wrong", specs)
+	assert.Nil(t, err)
+	cltest.CheckStatusCode(t, resp, 401)
+}
+
+func TestUsersController_Destroy(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	createUser(t, app, "analyst", "run-trigger")
+	resp := cltest.BasicAuthPost(
+		app.Server.URL+"/v2/api_tokens",
+		"application/json",
+		bytes.NewBufferString(`{"scope":"admin","owner":"analyst"}`),
+	)
+	cltest.CheckStatusCode(t, resp, 400)
+	token := cltest.BasicAuthPost(
+		app.Server.URL+"/v2/api_tokens",
+		"application/json",
+		bytes.NewBufferString(`{"scope":"read-only","owner":"analyst"}`),
+	)
+	cltest.CheckStatusCode(t, token, 200)
+	var created APITokenJSON
+	assert.Nil(t, json.Unmarshal(cltest.ParseResponseBody(token), &created))
+
+	specs := app.Server.URL + "/v2/specs"
+	cltest.CheckStatusCode(t, cltest.TokenAuthRequest("GET", specs, created.Token, nil), 200)
+
+	resp = cltest.BasicAuthDelete(app.Server.URL + "/v2/users/analyst")
+	cltest.CheckStatusCode(t, resp, 200)
+	cltest.CheckStatusCode(t, cltest.TokenAuthRequest("GET", specs, created.Token, nil), 401)
+	resp, err := utils.BasicAuthGet("analyst", "secret", specs)
+	assert.Nil(t, err)
+	cltest.CheckStatusCode(t, resp, 401)
+
+	resp = cltest.BasicAuthDelete(app.Server.URL + "/v2/users/analyst")
+	cltest.CheckStatusCode(t, resp, 404)
+}